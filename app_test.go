@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+// newTestApp ouvre l'application sur une base vide, dans un dossier temporaire
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewApp()
+	t.Cleanup(func() {
+		app.db.Close()
+		os.Chdir(wd)
+	})
+	return app
+}
+
+// insertTestCard enregistre une carte sans la scraper et retourne son identifiant
+func insertTestCard(t *testing.T, app *App, card Card) int {
+	t.Helper()
+
+	res, err := app.db.Exec(`
+		INSERT INTO cards (name, set_name, rarity, price, price_num, currency, image_url, card_url, type,
+			quality, language, edition, total_offers, game, marketplace)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, card.Name, card.Set, card.Rarity, card.Price, card.PriceNum, card.Currency, card.ImageURL, card.CardURL, card.Type,
+		card.Quality, card.Language, card.Edition, card.TotalOffers, card.Game, card.Marketplace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int(id)
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	// Nombre maximal d'onglets ouverts simultanément dans le navigateur partagé
+	browserPoolSize = 2
+
+	// Durée maximale du scraping d'une carte dans un onglet
+	scrapeTimeout = 3 * time.Minute
+)
+
+// browserTab est un onglet du navigateur partagé
+type browserTab struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// browserPool gère un navigateur unique, démarré à la demande et partagé entre les scrapings.
+// Les onglets partagent les cookies du navigateur, la bannière cookies de chaque site n'est donc traitée qu'une fois.
+type browserPool struct {
+	mu            sync.Mutex
+	opts          []chromedp.ExecAllocatorOption
+	allocCancel   context.CancelFunc
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+	idle          []*browserTab
+	slots         chan struct{}
+	cookiesDone   map[string]bool // Sites dont la bannière cookies a été traitée
+	closed        bool
+}
+
+func newBrowserPool(size int, opts []chromedp.ExecAllocatorOption) *browserPool {
+	return &browserPool{
+		opts:        opts,
+		slots:       make(chan struct{}, size),
+		cookiesDone: map[string]bool{},
+	}
+}
+
+// scrapeChromeOptions retourne les options Chrome utilisées par le navigateur partagé
+func scrapeChromeOptions() []chromedp.ExecAllocatorOption {
+	return append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("no-sandbox", true),
+		chromedp.Flag("disable-dev-shm-usage", true),
+		chromedp.Flag("disable-blink-features", "AutomationControlled"),
+		chromedp.UserAgent("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36"),
+	)
+}
+
+// startLocked démarre le navigateur s'il n'est pas lancé ou s'il a planté
+func (p *browserPool) startLocked() error {
+	if p.browserCtx != nil && p.browserCtx.Err() == nil {
+		return nil
+	}
+
+	if p.browserCtx != nil {
+		log.Println("♻️  Navigateur arrêté ou planté, redémarrage...")
+		p.stopLocked()
+	} else {
+		log.Println("🌐 Démarrage du navigateur partagé...")
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), p.opts...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+
+	// Lancer le processus Chrome
+	if err := chromedp.Run(browserCtx); err != nil {
+		browserCancel()
+		allocCancel()
+		return newScrapeError(ErrBrowserUnavailable, "impossible de se connecter au navigateur", err.Error())
+	}
+
+	p.allocCancel = allocCancel
+	p.browserCtx = browserCtx
+	p.browserCancel = browserCancel
+	p.cookiesDone = map[string]bool{}
+	log.Println("✅ Navigateur partagé démarré")
+	return nil
+}
+
+// stopLocked ferme les onglets et le navigateur
+func (p *browserPool) stopLocked() {
+	for _, tab := range p.idle {
+		tab.cancel()
+	}
+	p.idle = nil
+
+	if p.browserCancel != nil {
+		p.browserCancel()
+	}
+	if p.allocCancel != nil {
+		p.allocCancel()
+	}
+	p.browserCtx = nil
+	p.browserCancel = nil
+	p.allocCancel = nil
+}
+
+// Acquire retourne un onglet libre, en démarrant le navigateur si nécessaire
+func (p *browserPool) Acquire() (*browserTab, error) {
+	p.slots <- struct{}{}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		<-p.slots
+		return nil, newScrapeError(ErrBrowserUnavailable, "navigateur arrêté", "l'application est en cours de fermeture")
+	}
+
+	if err := p.startLocked(); err != nil {
+		<-p.slots
+		return nil, err
+	}
+
+	// Réutiliser un onglet encore valide
+	for len(p.idle) > 0 {
+		tab := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if tab.ctx.Err() == nil {
+			return tab, nil
+		}
+		tab.cancel()
+	}
+
+	// Ouvrir un nouvel onglet dans le navigateur partagé
+	ctx, cancel := chromedp.NewContext(p.browserCtx)
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		<-p.slots
+		return nil, newScrapeError(ErrBrowserUnavailable, "impossible d'ouvrir un onglet", err.Error())
+	}
+
+	return &browserTab{ctx: ctx, cancel: cancel}, nil
+}
+
+// Release rend l'onglet au pool ; un onglet dont le navigateur a échoué est fermé
+func (p *browserPool) Release(tab *browserTab, scrapeErr error) {
+	defer func() { <-p.slots }()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed || tab.ctx.Err() != nil || errors.Is(scrapeErr, ErrBrowserUnavailable) {
+		tab.cancel()
+		return
+	}
+
+	p.idle = append(p.idle, tab)
+}
+
+// CookiesHandled indique si la bannière cookies d'un site a déjà été traitée pour ce navigateur
+func (p *browserPool) CookiesHandled(site string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cookiesDone[site]
+}
+
+// MarkCookiesHandled mémorise que la bannière cookies d'un site a été traitée
+func (p *browserPool) MarkCookiesHandled(site string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cookiesDone[site] = true
+}
+
+// Close arrête le navigateur partagé
+func (p *browserPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+	p.closed = true
+
+	if p.browserCtx != nil {
+		log.Println("🛑 Arrêt du navigateur partagé")
+	}
+	p.stopLocked()
+}
+
+// Status décrit l'état du pool pour les informations système
+func (p *browserPool) Status() map[string]any {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return map[string]any{
+		"running":   p.browserCtx != nil && p.browserCtx.Err() == nil,
+		"idle_tabs": len(p.idle),
+		"busy_tabs": len(p.slots),
+		"max_tabs":  cap(p.slots),
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cardmarketMarketplace lit les pages produit de cardmarket.com avec le profil de sélecteurs actif
+type cardmarketMarketplace struct {
+	selectors *selectorStore
+}
+
+func (m *cardmarketMarketplace) ID() string       { return "cardmarket" }
+func (m *cardmarketMarketplace) Name() string     { return "CardMarket" }
+func (m *cardmarketMarketplace) Currency() string { return "EUR" }
+func (m *cardmarketMarketplace) Locale() string   { return canonicalLocale }
+
+func (m *cardmarketMarketplace) Recognizes(rawURL string) bool {
+	_, _, _, err := splitCardmarketPath(rawURL)
+	return err == nil
+}
+
+func (m *cardmarketMarketplace) ParseURL(rawURL string) (*ProductURL, error) {
+	parsed, err := parseCardmarketURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	game := parsed.Game
+	if profile, ok := gameProfile(game); ok {
+		game = profile.Code
+	}
+
+	return &ProductURL{
+		Marketplace: m.ID(),
+		Canonical:   parsed.Canonical,
+		Game:        game,
+		Criteria:    criteriaFromFilters(game, parsed.Filters),
+		MinQuality:  minQualityFromFilters(parsed.Filters),
+	}, nil
+}
+
+func (m *cardmarketMarketplace) FilteredURL(productURL string, req AddCardRequest) (string, bool) {
+	game := ""
+	if parsed, err := m.ParseURL(productURL); err == nil {
+		game = parsed.Game
+	}
+	return buildFilteredURL(productURL, game, req)
+}
+
+// Titres des pages d'erreur 404 de CardMarket, dans les langues du site
+var cardmarketNotFoundMarkers = []string{"404", "page not found", "page introuvable", "seite nicht gefunden"}
+
+func (m *cardmarketMarketplace) IsNotFoundPage(title string) bool {
+	title = strings.ToLower(title)
+	for _, marker := range cardmarketNotFoundMarkers {
+		if strings.Contains(title, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// CookieBanner retourne les sélecteurs du profil actif
+func (m *cardmarketMarketplace) CookieBanner() []string {
+	return m.selectors.Current().CookieBanner
+}
+
+// ExtractScript lit l'en-tête et toutes les offres chargées en une seule évaluation
+func (m *cardmarketMarketplace) ExtractScript() string {
+	return fmt.Sprintf(`
+		(function() {
+			var sel = %s;
+			var result = {title: document.title, url: window.location.href, lang: document.documentElement.lang || '', name: '', rarity: '', set_name: '', selector_version: sel.version, rows: []};
+
+			// En-tête : nom, rareté et set
+			var h1 = document.querySelector(sel.product_title);
+			result.has_title = h1 !== null;
+			result.name = h1 ? h1.innerText.trim() : '';
+
+			// Image du produit, éventuellement chargée à la demande
+			var img = sel.product_image ? document.querySelector(sel.product_image) : null;
+			result.image_url = img ? (img.getAttribute('data-echo') || img.getAttribute('data-src') || img.getAttribute('src') || '') : '';
+			try {
+				var infoContainer = document.querySelector(sel.info_container);
+				if (infoContainer) {
+					// Extraire la rareté depuis l'attribut de l'icône
+					var rarityElement = infoContainer.querySelector(sel.rarity);
+					result.rarity = rarityElement ? rarityElement.getAttribute(sel.rarity_attribute) : '';
+
+					// Extraire le nom du set - chercher le lien vers l'expansion
+					var setElement = infoContainer.querySelector(sel.set_link);
+					result.set_name = setElement ? setElement.textContent.trim() : '';
+				}
+			} catch(e) {
+				console.log('Erreur extraction:', e);
+			}
+
+			// Offres : une entrée par ligne d'offre
+			var rows = document.querySelectorAll(sel.offer_row);
+			for (var i = 0; i < rows.length; i++) {
+				var row = rows[i];
+				var offer = {};
+				try {
+					var mintEl = row.querySelector(sel.offer_condition);
+					offer.mint = mintEl ? mintEl.textContent.trim() : '';
+
+					var langEl = row.querySelector(sel.offer_language);
+					offer.langue = langEl ? (langEl.getAttribute('data-original-title') || langEl.getAttribute('title') || '') : '';
+
+					// Icônes spéciales : première édition, foil, reverse holo selon le jeu
+					var specialEls = row.querySelectorAll(sel.offer_first_edition);
+					offer.edition = specialEls.length > 0;
+					offer.specials = [];
+					for (var j = 0; j < specialEls.length; j++) {
+						var label = specialEls[j].getAttribute('data-bs-original-title') || specialEls[j].getAttribute('aria-label') || specialEls[j].getAttribute('title') || '';
+						if (label) offer.specials.push(label);
+					}
+
+					var priceEl = row.querySelector(sel.offer_price);
+					offer.price = priceEl ? priceEl.textContent.trim() : '';
+
+					offer.success = true;
+				} catch(e) {
+					offer.error = e.toString();
+					offer.success = false;
+				}
+				result.rows.push(offer);
+			}
+
+			// Page sans offre en vente : distinguer d'un parser cassé
+			if (rows.length === 0) {
+				var bodyText = document.body ? document.body.innerText : '';
+				result.empty_marker = (sel.empty_offers_markers || []).some(function(marker) {
+					return bodyText.indexOf(marker) !== -1;
+				});
+			}
+
+			return result;
+		})()
+	`, m.selectors.Current().jsObject())
+}
+
+func (m *cardmarketMarketplace) ParseProduct(payload *pagePayload) (*pageExtraction, string) {
+	game := ""
+	if parsed, err := m.ParseURL(payload.URL); err == nil {
+		game = parsed.Game
+	}
+
+	page := &pageExtraction{
+		Name:     strings.TrimSpace(payload.Name),
+		Rarity:   normalizeRarity(game, payload.Rarity),
+		SetName:  strings.TrimSpace(payload.SetName),
+		ImageURL: resolveImageURL(payload.URL, payload.ImageURL),
+		RowCount: len(payload.Rows),
+	}
+
+	// Page chargée mais illisible : dérive de la structure CardMarket
+	selectors := m.selectors.Current()
+	if !payload.HasTitle {
+		return page, fmt.Sprintf("aucun élément '%s' sur la page", selectors.ProductTitle)
+	}
+	if page.RowCount == 0 && !payload.EmptyMarker {
+		return page, fmt.Sprintf("aucune ligne '%s' et aucun message d'absence d'offre", selectors.OfferRow)
+	}
+
+	return page, ""
+}
+
+func (m *cardmarketMarketplace) ParseOffers(payload *pagePayload, page *pageExtraction) []CardOffer {
+	game := ""
+	if parsed, err := m.ParseURL(payload.URL); err == nil {
+		game = parsed.Game
+	}
+
+	var offers []CardOffer
+	for _, row := range payload.Rows {
+		if !row.Success {
+			continue
+		}
+		offers = append(offers, CardOffer{
+			Mint:     strings.TrimSpace(row.Mint),
+			Language: strings.TrimSpace(row.Langue),
+			Edition:  offerHasEdition(game, row.Edition, row.Specials),
+			Price:    strings.TrimSpace(row.Price),
+			Rarity:   page.Rarity,
+			SetName:  page.SetName,
+		})
+	}
+	return offers
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Locale utilisée pour les URLs canoniques : les libellés des offres (langues) sont lus en français
+const canonicalLocale = "fr"
+
+// Locales acceptées dans les URLs CardMarket
+var cardmarketLocales = map[string]bool{
+	"fr": true, "en": true, "de": true, "es": true, "it": true,
+}
+
+// Paramètres de requête correspondant à des filtres d'offres (les autres sont ignorés)
+var cardmarketFilterParams = map[string]bool{
+	"language":      true,
+	"minCondition":  true,
+	"isFirstEd":     true,
+	"isSigned":      true,
+	"isAltered":     true,
+	"isPlayset":     true,
+	"isReverseHolo": true,
+	"isFoil":        true,
+	"sellerCountry": true,
+	"sellerType":    true,
+}
+
+// CardmarketURL est une URL produit CardMarket décomposée
+type CardmarketURL struct {
+	Canonical string     `json:"canonical"`
+	Locale    string     `json:"locale"`
+	Game      string     `json:"game"`
+	Category  string     `json:"category"`
+	Expansion string     `json:"expansion"`
+	Product   string     `json:"product"`
+	Filters   url.Values `json:"filters"`
+}
+
+// splitCardmarketPath vérifie qu'une URL pointe vers CardMarket et retourne les segments du chemin,
+// sans la locale optionnelle
+func splitCardmarketPath(raw string) (*url.URL, []string, string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil, "", newScrapeError(ErrInvalidProductURL, "URL manquante", "")
+	}
+
+	parsed, err := parseLooseURL(raw)
+	if err != nil {
+		return nil, nil, "", newScrapeError(ErrInvalidProductURL, "URL invalide", err.Error())
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host != "cardmarket.com" && host != "www.cardmarket.com" {
+		return nil, nil, "", newScrapeError(ErrInvalidProductURL, "l'URL doit pointer vers cardmarket.com", raw)
+	}
+
+	segments := strings.FieldsFunc(parsed.Path, func(r rune) bool { return r == '/' })
+
+	// Locale optionnelle en tête du chemin
+	locale := canonicalLocale
+	if len(segments) > 0 && cardmarketLocales[strings.ToLower(segments[0])] {
+		locale = strings.ToLower(segments[0])
+		segments = segments[1:]
+	}
+
+	return parsed, segments, locale, nil
+}
+
+// parseCardmarketURL valide une URL de page produit CardMarket et calcule sa forme canonique
+func parseCardmarketURL(raw string) (*CardmarketURL, error) {
+	parsed, segments, locale, err := splitCardmarketPath(raw)
+	if err != nil {
+		return nil, err
+	}
+	raw = parsed.String()
+
+	// Forme attendue : /{Jeu}/Products/{Catégorie}/{Extension}/{Produit}
+	if len(segments) >= 2 && segments[1] == "Expansions" {
+		return nil, newScrapeError(ErrInvalidProductURL, "cette URL est une page d'extension, pas une page produit", raw)
+	}
+	if len(segments) >= 3 && segments[1] == "Products" && segments[2] == "Search" {
+		return nil, newScrapeError(ErrInvalidProductURL, "cette URL est une page de recherche, pas une page produit", raw)
+	}
+	if len(segments) != 5 || segments[1] != "Products" {
+		return nil, newScrapeError(ErrInvalidProductURL, "cette URL n'est pas une page produit CardMarket", raw)
+	}
+
+	result := &CardmarketURL{
+		Locale:    locale,
+		Game:      segments[0],
+		Category:  segments[2],
+		Expansion: segments[3],
+		Product:   segments[4],
+		Filters:   url.Values{},
+	}
+	result.Canonical = fmt.Sprintf("https://www.cardmarket.com/%s/%s/Products/%s/%s/%s",
+		canonicalLocale, result.Game, result.Category, result.Expansion, result.Product)
+
+	// Séparer les filtres d'offres des paramètres de suivi
+	for key, values := range parsed.Query() {
+		if cardmarketFilterParams[key] {
+			result.Filters[key] = values
+		}
+	}
+
+	return result, nil
+}
+
+// parseExpansionURL valide une URL d'extension CardMarket et retourne le jeu, l'extension
+// et l'URL de la liste de ses cartes. Accepte la page d'extension ou la liste des Singles.
+func parseExpansionURL(raw string) (game, expansion, listingURL string, err error) {
+	_, segments, _, err := splitCardmarketPath(raw)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// Formes attendues : /{Jeu}/Expansions/{Extension} ou /{Jeu}/Products/Singles/{Extension}
+	switch {
+	case len(segments) == 3 && segments[1] == "Expansions":
+		game, expansion = segments[0], segments[2]
+	case len(segments) == 4 && segments[1] == "Products" && segments[2] == "Singles":
+		game, expansion = segments[0], segments[3]
+	default:
+		return "", "", "", newScrapeError(ErrInvalidProductURL, "cette URL n'est pas une page d'extension CardMarket", raw)
+	}
+
+	listingURL = fmt.Sprintf("https://www.cardmarket.com/%s/%s/Products/Singles/%s", canonicalLocale, game, expansion)
+	return game, expansion, listingURL, nil
+}
+
+// canonicalCardURL retourne la forme canonique d'une URL, ou l'URL telle quelle si elle n'est pas reconnue
+func canonicalCardURL(raw string) string {
+	parsed, err := parseCardmarketURL(raw)
+	if err != nil {
+		return raw
+	}
+	return parsed.Canonical
+}
+
+// criteriaFromFilters lit les critères de recherche exacts présents dans les filtres d'une URL.
+// minCondition est un état minimal, lu à part par minQualityFromFilters.
+func criteriaFromFilters(game string, filters url.Values) CardCriteria {
+	var criteria CardCriteria
+
+	if id, err := strconv.Atoi(filters.Get("language")); err == nil {
+		criteria.Language = cardmarketLanguageNames[id]
+	}
+
+	if param := editionFilterParam(game); param != "" && filters.Get(param) == "Y" {
+		criteria.Edition = true
+	}
+
+	return criteria
+}
+
+// minQualityFromFilters lit l'état minimal du filtre minCondition : le site affiche aussi les états supérieurs
+func minQualityFromFilters(filters url.Values) string {
+	if id, err := strconv.Atoi(filters.Get("minCondition")); err == nil {
+		for code, conditionID := range cardmarketConditionIDs {
+			if conditionID == id {
+				return code
+			}
+		}
+	}
+	return ""
+}
+
+// migrateCanonicalURLs convertit les URLs déjà enregistrées vers leur forme canonique
+func (a *App) migrateCanonicalURLs() {
+	rows, err := a.db.Query("SELECT id, card_url FROM cards")
+	if err != nil {
+		log.Printf("Erreur lors de la lecture des URLs: %v", err)
+		return
+	}
+
+	updates := map[int]string{}
+	for rows.Next() {
+		var id int
+		var cardURL string
+		if err := rows.Scan(&id, &cardURL); err != nil {
+			continue
+		}
+		if canonical := canonicalCardURL(cardURL); canonical != cardURL {
+			updates[id] = canonical
+		}
+	}
+	rows.Close()
+
+	for id, canonical := range updates {
+		_, err := a.db.Exec("UPDATE cards SET card_url = ? WHERE id = ?", canonical, id)
+		if err != nil {
+			// Une autre carte utilise déjà cette URL canonique : doublon existant, laissé tel quel
+			log.Printf("⚠️  URL de la carte %d non canonisée (%s): %v", id, canonical, err)
+		}
+	}
+
+	if len(updates) > 0 {
+		log.Printf("🔗 %d URL(s) de cartes converties en forme canonique", len(updates))
+	}
+}
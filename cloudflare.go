@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	// Attente maximale pour qu'un challenge Cloudflare se résolve seul
+	challengeWaitTimeout = 15 * time.Second
+
+	// Paramètres du backoff exponentiel appliqué après un blocage
+	backoffBase = 5 * time.Second
+	backoffMax  = 90 * time.Second
+
+	// Nombre de blocages consécutifs avant de mettre le rescrap en pause
+	maxConsecutiveChallenges = 4
+)
+
+// Titres de page utilisés par Cloudflare pendant un challenge
+var challengeTitles = []string{
+	"just a moment",
+	"un instant",
+	"einen moment",
+	"attention required",
+	"checking your browser",
+	"access denied",
+}
+
+// challengeMarkersScript détecte les éléments caractéristiques d'une page de challenge
+const challengeMarkersScript = `
+	(function() {
+		return document.querySelector(
+			'#challenge-form, #challenge-running, #challenge-stage, #cf-challenge-running, ' +
+			'.cf-browser-verification, #cf-wrapper, script[src*="challenge-platform"], iframe[src*="challenges.cloudflare.com"]'
+		) !== null;
+	})()
+`
+
+// isChallengeTitle indique si le titre correspond à une page de challenge
+func isChallengeTitle(title string) bool {
+	title = strings.ToLower(title)
+	for _, marker := range challengeTitles {
+		if strings.Contains(title, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isChallengeError indique si l'erreur correspond à un blocage anti-bot
+func isChallengeError(err error) bool {
+	return errors.Is(err, ErrCloudflareChallenge) || errors.Is(err, ErrRateLimited)
+}
+
+// detectChallenge vérifie si la page courante est une page de challenge ou de limitation
+func (a *App) detectChallenge(ctx context.Context, status int64) error {
+	if status == 429 {
+		return newScrapeError(ErrRateLimited, ErrRateLimited.Error(), "HTTP 429")
+	}
+
+	var title string
+	var hasMarkers bool
+	err := chromedp.Run(ctx,
+		chromedp.Title(&title),
+		chromedp.Evaluate(challengeMarkersScript, &hasMarkers),
+	)
+	if err != nil {
+		return classifyScrapeError(fmt.Errorf("erreur lors de la détection du challenge: %w", err))
+	}
+
+	if hasMarkers || isChallengeTitle(title) || status == 403 {
+		return newScrapeError(ErrCloudflareChallenge, ErrCloudflareChallenge.Error(),
+			fmt.Sprintf("HTTP %d, titre=%q", status, title))
+	}
+
+	return nil
+}
+
+// waitForChallenge laisse au challenge le temps de se résoudre avant de conclure à un blocage
+func (a *App) waitForChallenge(ctx context.Context, status int64) error {
+	deadline := time.Now().Add(challengeWaitTimeout)
+
+	for {
+		err := a.detectChallenge(ctx, status)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrCloudflareChallenge) || time.Now().After(deadline) {
+			return err
+		}
+
+		log.Println("🛡️  Challenge Cloudflare en cours, attente de la résolution...")
+		if err := chromedp.Run(ctx, chromedp.Sleep(2*time.Second)); err != nil {
+			return classifyScrapeError(err)
+		}
+
+		// Le statut HTTP initial ne reflète plus la page une fois le challenge résolu
+		status = 0
+	}
+}
+
+// scrapeBackoff applique un backoff exponentiel avec jitter après les blocages anti-bot
+type scrapeBackoff struct {
+	mu       sync.Mutex
+	failures int
+	base     time.Duration
+	max      time.Duration
+}
+
+func newScrapeBackoff(base, max time.Duration) *scrapeBackoff {
+	return &scrapeBackoff{base: base, max: max}
+}
+
+// Delay retourne l'attente à respecter avant le prochain scraping
+func (b *scrapeBackoff) Delay() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures == 0 {
+		return 0
+	}
+
+	delay := b.base << (b.failures - 1)
+	if delay <= 0 || delay > b.max {
+		delay = b.max
+	}
+
+	// Jitter : entre la moitié et la totalité du délai
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// Failure enregistre un blocage et retourne le nombre de blocages consécutifs
+func (b *scrapeBackoff) Failure() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	return b.failures
+}
+
+// Success réinitialise le backoff
+func (b *scrapeBackoff) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+// Failures retourne le nombre de blocages consécutifs
+func (b *scrapeBackoff) Failures() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// CriteriaChange est une modification des critères d'une carte
+type CriteriaChange struct {
+	ID          int    `json:"id"`
+	CardID      int    `json:"card_id"`
+	OldQuality  string `json:"old_quality"`
+	OldLanguage string `json:"old_language"`
+	OldEdition  bool   `json:"old_edition"`
+	NewQuality  string `json:"new_quality"`
+	NewLanguage string `json:"new_language"`
+	NewEdition  bool   `json:"new_edition"`
+	Rescraped   bool   `json:"rescraped"`
+	ChangedAt   string `json:"changed_at"`
+}
+
+// validateCriteria vérifie des critères de recherche d'offre et les ramène à leur orthographe de référence
+func validateCriteria(game, quality, language string, edition bool) (string, string, error) {
+	quality = strings.ToUpper(strings.TrimSpace(quality))
+	if _, ok := cardmarketConditionIDs[quality]; !ok {
+		return "", "", fmt.Errorf("qualité inconnue: %q (valeurs possibles: MT, NM, EX, GD, LP, PL, PO)", quality)
+	}
+
+	language = strings.TrimSpace(language)
+	known := false
+	for _, name := range cardmarketLanguageNames {
+		if strings.EqualFold(name, language) {
+			language, known = name, true
+			break
+		}
+	}
+	if !known {
+		return "", "", fmt.Errorf("langue inconnue: %q", language)
+	}
+
+	if profile, ok := gameProfile(game); ok && edition && profile.EditionLabel == "" {
+		return "", "", fmt.Errorf("le jeu %s n'a pas d'attribut spécial à suivre", profile.Name)
+	}
+
+	return quality, language, nil
+}
+
+// UpdateCardCriteria modifie la qualité, la langue et l'édition suivies d'une carte.
+// Avec rescrape, la carte est rescrapée immédiatement et les critères ne sont enregistrés que si une offre est trouvée.
+func (a *App) UpdateCardCriteria(cardID int, quality, language string, edition, rescrape bool) (*Card, error) {
+	card, err := a.getCardByID(cardID)
+	if err != nil {
+		return nil, fmt.Errorf("carte %d introuvable: %v", cardID, err)
+	}
+
+	quality, language, err = validateCriteria(card.Game, quality, language, edition)
+	if err != nil {
+		return nil, err
+	}
+	if quality == card.Quality && language == card.Language && edition == card.Edition && !rescrape {
+		return card, nil
+	}
+
+	updated := card
+	if rescrape {
+		req := cardRequest(card)
+		req.Quality, req.Language, req.Edition = quality, language, edition
+		refresh, err := a.refreshCard(card, req)
+		if err != nil {
+			return nil, err
+		}
+		updated = refresh.Card
+	} else {
+		_, err := a.db.Exec(`
+			UPDATE cards SET quality = ?, language = ?, edition = ?, last_updated = CURRENT_TIMESTAMP
+			WHERE id = ?
+		`, quality, language, edition, cardID)
+		if err != nil {
+			return nil, fmt.Errorf("erreur sauvegarde: %v", err)
+		}
+		updated.Quality, updated.Language, updated.Edition = quality, language, edition
+	}
+
+	a.recordCriteriaChange(card, AddCardRequest{Quality: quality, Language: language, Edition: edition}, rescrape)
+	return updated, nil
+}
+
+// recordCriteriaChange ajoute à l'historique le passage des critères de card à ceux de req, s'ils diffèrent
+func (a *App) recordCriteriaChange(card *Card, req AddCardRequest, rescraped bool) {
+	if req.Quality == card.Quality && req.Language == card.Language && req.Edition == card.Edition {
+		return
+	}
+
+	_, err := a.db.Exec(`
+		INSERT INTO criteria_changes (card_id, old_quality, old_language, old_edition, new_quality, new_language, new_edition, rescraped)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, card.ID, card.Quality, card.Language, card.Edition, req.Quality, req.Language, req.Edition, rescraped)
+	if err != nil {
+		log.Printf("⚠️  Changement de critères de la carte %d non enregistré: %v", card.ID, err)
+	}
+	log.Printf("✏️  Critères de la carte %d: %s/%s/%t -> %s/%s/%t", card.ID,
+		card.Quality, card.Language, card.Edition, req.Quality, req.Language, req.Edition)
+}
+
+// GetCriteriaChanges retourne l'historique des changements de critères d'une carte, du plus récent au plus ancien
+func (a *App) GetCriteriaChanges(cardID int) ([]CriteriaChange, error) {
+	rows, err := a.db.Query(`
+		SELECT id, card_id, old_quality, old_language, old_edition, new_quality, new_language, new_edition, rescraped, changed_at
+		FROM criteria_changes
+		WHERE card_id = ?
+		ORDER BY id DESC
+	`, cardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []CriteriaChange{}
+	for rows.Next() {
+		var change CriteriaChange
+		err := rows.Scan(&change.ID, &change.CardID, &change.OldQuality, &change.OldLanguage, &change.OldEdition,
+			&change.NewQuality, &change.NewLanguage, &change.NewEdition, &change.Rescraped, &change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
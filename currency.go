@@ -0,0 +1,459 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Devise de référence des taux de change : un taux est le nombre d'unités de la devise pour 1 EUR
+const (
+	baseCurrency           = "EUR"
+	defaultDisplayCurrency = "EUR"
+)
+
+// CurrencyInfo décrit une devise reconnue
+type CurrencyInfo struct {
+	Code   string `json:"code"` // Code ISO 4217
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+// Devises reconnues dans les prix affichés par les sites
+var supportedCurrencies = []CurrencyInfo{
+	{Code: "EUR", Symbol: "€", Name: "Euro"},
+	{Code: "USD", Symbol: "$", Name: "Dollar américain"},
+	{Code: "GBP", Symbol: "£", Name: "Livre sterling"},
+	{Code: "CHF", Symbol: "CHF", Name: "Franc suisse"},
+	{Code: "JPY", Symbol: "¥", Name: "Yen"},
+	{Code: "CAD", Symbol: "CA$", Name: "Dollar canadien"},
+	{Code: "AUD", Symbol: "AU$", Name: "Dollar australien"},
+	{Code: "PLN", Symbol: "zł", Name: "Zloty"},
+	{Code: "SEK", Symbol: "kr", Name: "Couronne suédoise"},
+	{Code: "DKK", Symbol: "kr.", Name: "Couronne danoise"},
+	{Code: "NOK", Symbol: "kr", Name: "Couronne norvégienne"},
+	{Code: "CZK", Symbol: "Kč", Name: "Couronne tchèque"},
+}
+
+// Marqueurs de devise dans un prix, du plus spécifique au plus général :
+// "CA$" doit être reconnu avant "$", les codes ISO avant les symboles
+var currencyMarkers = []struct {
+	Marker string
+	Code   string
+}{
+	{"US$", "USD"},
+	{"CA$", "CAD"},
+	{"C$", "CAD"},
+	{"AU$", "AUD"},
+	{"A$", "AUD"},
+	{"EUR", "EUR"},
+	{"USD", "USD"},
+	{"GBP", "GBP"},
+	{"CHF", "CHF"},
+	{"JPY", "JPY"},
+	{"CAD", "CAD"},
+	{"AUD", "AUD"},
+	{"PLN", "PLN"},
+	{"SEK", "SEK"},
+	{"DKK", "DKK"},
+	{"NOK", "NOK"},
+	{"CZK", "CZK"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"¥", "JPY"},
+	{"￥", "JPY"},
+	{"zł", "PLN"},
+	{"Kč", "CZK"},
+	{"Fr.", "CHF"},
+	{"$", "USD"},
+}
+
+// Marqueurs des couronnes scandinaves, communs à SEK, NOK et DKK
+var kronaMarkers = []string{"kr.", "kr", "Kr.", "Kr"}
+
+// Couronne désignée par "kr" selon la langue de la page
+var kronaByLanguage = map[string]string{
+	"sv": "SEK",
+	"da": "DKK",
+	"nb": "NOK",
+	"nn": "NOK",
+	"no": "NOK",
+}
+
+// detectKrona retourne la couronne d'un prix affiché en "kr", d'après la langue de la page ou la devise du site.
+// found est faux si le prix n'indique pas de couronne ; code est vide si la couronne ne peut être déterminée.
+func detectKrona(priceText string, hint priceHint) (code string, found bool) {
+	for _, marker := range kronaMarkers {
+		if strings.Contains(priceText, marker) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(hint.Locale)), "-")
+	if code, ok := kronaByLanguage[language]; ok {
+		return code, true
+	}
+	for _, code := range kronaByLanguage {
+		if code == hint.Currency {
+			return code, true
+		}
+	}
+	return "", true
+}
+
+// isSupportedCurrency indique si le code est une devise reconnue
+func isSupportedCurrency(code string) bool {
+	for _, currency := range supportedCurrencies {
+		if currency.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// detectCurrency retourne la devise indiquée dans un prix, ou la devise du site si aucune n'apparaît
+func detectCurrency(priceText, fallback string) string {
+	for _, marker := range currencyMarkers {
+		if strings.Contains(priceText, marker.Marker) {
+			return marker.Code
+		}
+	}
+	return fallback
+}
+
+// currencyOrDefault retourne la devise d'un prix, EUR pour les prix enregistrés avant le support multi-devises
+func currencyOrDefault(code string) string {
+	if code == "" {
+		return baseCurrency
+	}
+	return code
+}
+
+// ExchangeRate est le nombre d'unités d'une devise pour 1 EUR
+type ExchangeRate struct {
+	Currency  string  `json:"currency"`
+	Rate      float64 `json:"rate"`
+	Source    string  `json:"source"` // "manual" ou nom du fichier importé
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// CurrencySettings regroupe la devise d'affichage et les devises disponibles
+type CurrencySettings struct {
+	DisplayCurrency string         `json:"display_currency"`
+	Currencies      []CurrencyInfo `json:"currencies"`
+	Rates           []ExchangeRate `json:"rates"`
+}
+
+// getDisplayCurrency retourne la devise dans laquelle les totaux sont affichés
+func (a *App) getDisplayCurrency() string {
+	return a.getSetting("currency.display", defaultDisplayCurrency)
+}
+
+// GetCurrencySettings retourne la devise d'affichage et les taux de change connus
+func (a *App) GetCurrencySettings() (*CurrencySettings, error) {
+	rates, err := a.GetExchangeRates()
+	if err != nil {
+		return nil, err
+	}
+	return &CurrencySettings{
+		DisplayCurrency: a.getDisplayCurrency(),
+		Currencies:      supportedCurrencies,
+		Rates:           rates,
+	}, nil
+}
+
+// SetDisplayCurrency choisit la devise d'affichage des totaux
+func (a *App) SetDisplayCurrency(code string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !isSupportedCurrency(code) {
+		return fmt.Errorf("devise non supportée: %s", code)
+	}
+	if err := a.setSetting("currency.display", code); err != nil {
+		return fmt.Errorf("erreur sauvegarde réglages: %v", err)
+	}
+	return nil
+}
+
+// GetExchangeRates retourne les taux de change enregistrés, EUR compris
+func (a *App) GetExchangeRates() ([]ExchangeRate, error) {
+	rows, err := a.db.Query("SELECT currency, rate, COALESCE(source, ''), COALESCE(updated_at, '') FROM exchange_rates ORDER BY currency")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []ExchangeRate{{Currency: baseCurrency, Rate: 1, Source: "référence"}}
+	for rows.Next() {
+		var rate ExchangeRate
+		if err := rows.Scan(&rate.Currency, &rate.Rate, &rate.Source, &rate.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// SetExchangeRate enregistre manuellement le taux d'une devise (unités pour 1 EUR)
+func (a *App) SetExchangeRate(code string, rate float64) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == baseCurrency {
+		return fmt.Errorf("le taux de l'euro est fixé à 1")
+	}
+	if !isSupportedCurrency(code) {
+		return fmt.Errorf("devise non supportée: %s", code)
+	}
+	if rate <= 0 {
+		return fmt.Errorf("le taux de change doit être positif")
+	}
+	return a.saveExchangeRate(code, rate, "manual")
+}
+
+func (a *App) saveExchangeRate(code string, rate float64, source string) error {
+	_, err := a.db.Exec(`
+		INSERT INTO exchange_rates (currency, rate, source, updated_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(currency) DO UPDATE SET rate = excluded.rate, source = excluded.source, updated_at = excluded.updated_at
+	`, code, rate, source)
+	if err != nil {
+		return fmt.Errorf("erreur sauvegarde taux %s: %v", code, err)
+	}
+	return nil
+}
+
+// ImportExchangeRates importe les taux d'un fichier local, choisi dans une boîte de dialogue si path est vide.
+// Formats acceptés :
+//   - JSON : {"base": "EUR", "rates": {"USD": 1.08, "GBP": 0.85}}
+//   - CSV : une ligne "devise,taux" par devise, avec ou sans en-tête
+//
+// Les taux exprimés dans une autre base que l'euro sont convertis si le fichier contient le taux EUR.
+func (a *App) ImportExchangeRates(path string) ([]ExchangeRate, error) {
+	if path == "" {
+		if a.ctx == nil {
+			return nil, fmt.Errorf("aucun fichier de taux indiqué")
+		}
+		selected, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title: "Importer des taux de change",
+			Filters: []runtime.FileFilter{
+				{DisplayName: "Taux de change (*.json, *.csv)", Pattern: "*.json;*.csv"},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if selected == "" {
+			return a.GetExchangeRates()
+		}
+		path = selected
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("fichier de taux illisible: %v", err)
+	}
+	defer file.Close()
+
+	var base string
+	var rates map[string]float64
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		base, rates, err = parseRatesCSV(file)
+	} else {
+		base, rates, err = parseRatesJSON(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err = rebaseRates(base, rates)
+	if err != nil {
+		return nil, err
+	}
+
+	source := filepath.Base(path)
+	var imported, ignored []string
+	for code, rate := range rates {
+		if code == baseCurrency {
+			continue
+		}
+		if !isSupportedCurrency(code) || rate <= 0 {
+			ignored = append(ignored, code)
+			continue
+		}
+		if err := a.saveExchangeRate(code, rate, source); err != nil {
+			return nil, err
+		}
+		imported = append(imported, code)
+	}
+	sort.Strings(imported)
+
+	log.Printf("💱 %d taux de change importés depuis %s: %s", len(imported), source, strings.Join(imported, ", "))
+	if len(ignored) > 0 {
+		log.Printf("⚠️  Taux ignorés (devise non supportée ou taux invalide): %s", strings.Join(ignored, ", "))
+	}
+
+	return a.GetExchangeRates()
+}
+
+func parseRatesJSON(r io.Reader) (string, map[string]float64, error) {
+	var file struct {
+		Base  string             `json:"base"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return "", nil, fmt.Errorf("fichier de taux JSON invalide: %v", err)
+	}
+	if len(file.Rates) == 0 {
+		return "", nil, fmt.Errorf("aucun taux dans le fichier")
+	}
+	return file.Base, file.Rates, nil
+}
+
+func parseRatesCSV(r io.Reader) (string, map[string]float64, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return "", nil, fmt.Errorf("fichier de taux CSV invalide: %v", err)
+	}
+
+	rates := map[string]float64{}
+	for i, record := range records {
+		if len(record) < 2 {
+			continue
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			if i == 0 {
+				continue // En-tête
+			}
+			return "", nil, fmt.Errorf("ligne %d: taux invalide %q", i+1, record[1])
+		}
+		rates[strings.ToUpper(strings.TrimSpace(record[0]))] = rate
+	}
+	if len(rates) == 0 {
+		return "", nil, fmt.Errorf("aucun taux dans le fichier")
+	}
+	return baseCurrency, rates, nil
+}
+
+// rebaseRates exprime des taux d'une base quelconque en unités pour 1 EUR
+func rebaseRates(base string, rates map[string]float64) (map[string]float64, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	normalized := map[string]float64{}
+	for code, rate := range rates {
+		normalized[strings.ToUpper(strings.TrimSpace(code))] = rate
+	}
+	if base == "" || base == baseCurrency {
+		return normalized, nil
+	}
+
+	eurRate, ok := normalized[baseCurrency]
+	if !ok || eurRate <= 0 {
+		return nil, fmt.Errorf("taux en base %s sans taux EUR: conversion impossible", base)
+	}
+
+	rebased := map[string]float64{base: 1 / eurRate}
+	for code, rate := range normalized {
+		if code != base {
+			rebased[code] = rate / eurRate
+		}
+	}
+	return rebased, nil
+}
+
+// exchangeRates retourne les taux enregistrés indexés par devise, EUR compris
+func (a *App) exchangeRates() (map[string]float64, error) {
+	list, err := a.GetExchangeRates()
+	if err != nil {
+		return nil, err
+	}
+	rates := map[string]float64{}
+	for _, rate := range list {
+		rates[rate.Currency] = rate.Rate
+	}
+	return rates, nil
+}
+
+// convertAmount convertit un montant d'une devise à une autre via l'euro
+func convertAmount(amount float64, from, to string, rates map[string]float64) (float64, bool) {
+	from, to = currencyOrDefault(from), currencyOrDefault(to)
+	if from == to {
+		return amount, true
+	}
+	fromRate, okFrom := rates[from]
+	toRate, okTo := rates[to]
+	if !okFrom || !okTo || fromRate <= 0 {
+		return 0, false
+	}
+	return amount / fromRate * toRate, true
+}
+
+// sumInDisplayCurrency additionne price_num des cartes sélectionnées par la condition, par devise,
+// puis convertit chaque sous-total dans la devise d'affichage.
+// Les devises sans taux de change sont retournées au lieu d'être additionnées telles quelles.
+func (a *App) sumInDisplayCurrency(where string, args ...any) (int, float64, []string, error) {
+	rows, err := a.db.Query(`
+		SELECT COALESCE(NULLIF(currency, ''), 'EUR'), COUNT(*), COALESCE(SUM(price_num), 0)
+		FROM cards
+		WHERE `+where+`
+		GROUP BY 1
+	`, args...)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	defer rows.Close()
+
+	rates, err := a.exchangeRates()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	display := a.getDisplayCurrency()
+
+	var count int
+	var total float64
+	var missing []string
+	for rows.Next() {
+		var currency string
+		var n int
+		var sum float64
+		if err := rows.Scan(&currency, &n, &sum); err != nil {
+			return 0, 0, nil, err
+		}
+		count += n
+
+		converted, ok := convertAmount(sum, currency, display, rates)
+		if !ok {
+			missing = append(missing, currency)
+			continue
+		}
+		total += converted
+	}
+	return count, total, missing, rows.Err()
+}
+
+// mergeCurrencies réunit des listes de devises sans doublon
+func mergeCurrencies(lists ...[]string) []string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, list := range lists {
+		for _, code := range list {
+			if !seen[code] {
+				seen[code] = true
+				merged = append(merged, code)
+			}
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
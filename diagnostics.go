@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Dossier où sont enregistrées les captures de pages non reconnues
+const diagnosticsDir = "./diagnostics"
+
+// Conservation des diagnostics : les plus récents uniquement, et pas au-delà d'une durée maximale
+const (
+	maxDiagnostics   = 50
+	diagnosticMaxAge = 30 * 24 * time.Hour
+)
+
+// ScrapeDiagnostic décrit une page chargée que le parser n'a pas su lire
+type ScrapeDiagnostic struct {
+	ID              int    `json:"id"`
+	CardURL         string `json:"card_url"`
+	Kind            string `json:"kind"` // "layout_drift"
+	Reason          string `json:"reason"`
+	HTMLPath        string `json:"html_path"`
+	ScreenshotPath  string `json:"screenshot_path"`
+	SelectorVersion int    `json:"selector_version"`
+	CreatedAt       string `json:"created_at"`
+}
+
+var unsafePathChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// captureLayoutDrift sauvegarde le HTML et une capture de la page, puis enregistre l'événement en base
+func (a *App) captureLayoutDrift(ctx context.Context, pageURL, reason string, selectorVersion int) (*ScrapeDiagnostic, error) {
+	log.Printf("🧭 Dérive de structure détectée sur %s: %s", pageURL, reason)
+
+	slug := unsafePathChars.ReplaceAllString(filepath.Base(pageURL), "_")
+	if len(slug) > 60 {
+		slug = slug[:60]
+	}
+	dir := filepath.Join(diagnosticsDir, time.Now().Format("20060102-150405")+"_"+slug)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("création du dossier de diagnostic impossible: %v", err)
+	}
+
+	diag := &ScrapeDiagnostic{
+		CardURL:         pageURL,
+		Kind:            "layout_drift",
+		Reason:          reason,
+		SelectorVersion: selectorVersion,
+		CreatedAt:       time.Now().Format("2006-01-02 15:04:05"),
+	}
+
+	// Capturer la page avec un délai court pour ne pas bloquer le scraping
+	captureCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	var html string
+	if err := chromedp.Run(captureCtx, chromedp.OuterHTML("html", &html, chromedp.ByQuery)); err != nil {
+		log.Printf("⚠️  Capture HTML impossible: %v", err)
+	} else {
+		diag.HTMLPath = filepath.Join(dir, "page.html")
+		if err := os.WriteFile(diag.HTMLPath, []byte(html), 0o644); err != nil {
+			log.Printf("⚠️  Écriture HTML impossible: %v", err)
+			diag.HTMLPath = ""
+		}
+	}
+
+	var screenshot []byte
+	if err := chromedp.Run(captureCtx, chromedp.FullScreenshot(&screenshot, 100)); err != nil {
+		log.Printf("⚠️  Capture d'écran impossible: %v", err)
+	} else {
+		diag.ScreenshotPath = filepath.Join(dir, "screenshot.png")
+		if err := os.WriteFile(diag.ScreenshotPath, screenshot, 0o644); err != nil {
+			log.Printf("⚠️  Écriture capture d'écran impossible: %v", err)
+			diag.ScreenshotPath = ""
+		}
+	}
+
+	result, err := a.db.Exec(`
+		INSERT INTO scrape_diagnostics (card_url, kind, reason, html_path, screenshot_path, selector_version, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, diag.CardURL, diag.Kind, diag.Reason, diag.HTMLPath, diag.ScreenshotPath, diag.SelectorVersion, diag.CreatedAt)
+	if err != nil {
+		return diag, fmt.Errorf("enregistrement du diagnostic impossible: %v", err)
+	}
+
+	id, _ := result.LastInsertId()
+	diag.ID = int(id)
+	log.Printf("📁 Diagnostic #%d enregistré dans %s", diag.ID, dir)
+
+	a.pruneDiagnostics()
+	return diag, nil
+}
+
+// pruneDiagnostics supprime les captures et les diagnostics au-delà des maxDiagnostics plus récents
+// ou plus anciens que diagnosticMaxAge
+func (a *App) pruneDiagnostics() {
+	cutoff := time.Now().Add(-diagnosticMaxAge)
+
+	_, err := a.db.Exec(`
+		DELETE FROM scrape_diagnostics
+		WHERE created_at < ? OR id NOT IN (SELECT id FROM scrape_diagnostics ORDER BY id DESC LIMIT ?)
+	`, cutoff.Format("2006-01-02 15:04:05"), maxDiagnostics)
+	if err != nil {
+		log.Printf("⚠️  Nettoyage des diagnostics impossible: %v", err)
+	}
+
+	// Un dossier par capture, nommé par sa date : les plus récents en premier
+	entries, err := os.ReadDir(diagnosticsDir)
+	if err != nil {
+		return
+	}
+	removed, kept := 0, 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err == nil && kept < maxDiagnostics && info.ModTime().After(cutoff) {
+			kept++
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(diagnosticsDir, entry.Name())); err != nil {
+			log.Printf("⚠️  Suppression de %s impossible: %v", entry.Name(), err)
+			continue
+		}
+		removed++
+	}
+	if removed > 0 {
+		log.Printf("🧹 Diagnostics: %d capture(s) supprimée(s), %d conservée(s)", removed, kept)
+	}
+}
+
+// GetScrapeDiagnostics retourne les derniers diagnostics de scraping
+func (a *App) GetScrapeDiagnostics() ([]ScrapeDiagnostic, error) {
+	rows, err := a.db.Query(`
+		SELECT id, card_url, kind, reason, COALESCE(html_path, ''), COALESCE(screenshot_path, ''),
+		       COALESCE(selector_version, 0), created_at
+		FROM scrape_diagnostics
+		ORDER BY id DESC
+		LIMIT 100
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var diagnostics []ScrapeDiagnostic
+	for rows.Next() {
+		var diag ScrapeDiagnostic
+		err := rows.Scan(&diag.ID, &diag.CardURL, &diag.Kind, &diag.Reason, &diag.HTMLPath,
+			&diag.ScreenshotPath, &diag.SelectorVersion, &diag.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		diagnostics = append(diagnostics, diag)
+	}
+
+	return diagnostics, nil
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Nombre maximal de pages de liste parcourues pour une extension
+const maxExpansionPages = 50
+
+// CardCriteria regroupe les critères de recherche d'une offre
+type CardCriteria struct {
+	Quality  string `json:"quality"`
+	Language string `json:"language"`
+	Edition  bool   `json:"edition"`
+}
+
+// ImportSummary résume l'import d'une extension ou d'une liste d'adresses, reprises comprises
+type ImportSummary struct {
+	JobID        int      `json:"job_id"`
+	Game         string   `json:"game"`
+	Expansion    string   `json:"expansion"`
+	Total        int      `json:"total"`
+	Added        int      `json:"added"`
+	Skipped      int      `json:"skipped"`
+	Failed       int      `json:"failed"`
+	ErrorDetails []string `json:"error_details"`
+	Paused       bool     `json:"paused"`
+	PausedReason string   `json:"paused_reason"`
+	Remaining    int      `json:"remaining"`
+}
+
+// importJobParams sont les paramètres enregistrés d'un job d'import, pour pouvoir le reprendre
+type importJobParams struct {
+	CardType  string       `json:"card_type"`
+	Criteria  CardCriteria `json:"criteria"`
+	Game      string       `json:"game,omitempty"`
+	Expansion string       `json:"expansion,omitempty"`
+}
+
+// ImportExpansion ajoute toutes les cartes d'une extension avec les mêmes critères.
+// Les cartes déjà suivies sont ignorées. L'import est enregistré comme un job, repris avec ResumeJob s'il est interrompu.
+func (a *App) ImportExpansion(expansionURL, cardType string, criteria CardCriteria) (*ImportSummary, error) {
+	game, expansion, listingURL, err := parseExpansionURL(expansionURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.requireList(cardType); err != nil {
+		return nil, err
+	}
+	log.Printf("📦 Import de l'extension %s (%s) dans %s", expansion, game, cardType)
+
+	products, err := a.scrapeExpansionProducts(listingURL)
+	if err != nil {
+		log.Printf("❌ Erreur lecture de l'extension: %v", err)
+		return nil, classifyScrapeError(err)
+	}
+	log.Printf("📊 %d produits trouvés dans l'extension %s", len(products), expansion)
+
+	items := make([]JobItem, len(products))
+	for i, product := range products {
+		items[i] = JobItem{Key: product.ProductURL, Label: product.Name}
+	}
+	job, err := a.createJob("expansion_import", fmt.Sprintf("Import de l'extension %s", expansion),
+		importJobParams{CardType: cardType, Criteria: criteria, Game: game, Expansion: expansion}, items)
+	if err != nil {
+		return nil, err
+	}
+	return a.runImportJob(job)
+}
+
+// ImportCards ajoute une liste d'adresses produit avec les mêmes critères.
+// Les cartes déjà suivies sont ignorées. L'import est enregistré comme un job, repris avec ResumeJob s'il est interrompu.
+func (a *App) ImportCards(urls []string, cardType string, criteria CardCriteria) (*ImportSummary, error) {
+	if err := a.requireList(cardType); err != nil {
+		return nil, err
+	}
+
+	items := []JobItem{}
+	for _, url := range urls {
+		if url = strings.TrimSpace(url); url != "" {
+			items = append(items, JobItem{Key: url, Label: url})
+		}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("aucune adresse à importer")
+	}
+	log.Printf("📦 Import de %d adresse(s) dans %s", len(items), cardType)
+
+	job, err := a.createJob("import", fmt.Sprintf("Import de %d carte(s)", len(items)),
+		importJobParams{CardType: cardType, Criteria: criteria}, items)
+	if err != nil {
+		return nil, err
+	}
+	return a.runImportJob(job)
+}
+
+// runImportJob ajoute les cartes en attente d'un job d'import, via le même circuit que le rescrap
+func (a *App) runImportJob(job *Job) (*ImportSummary, error) {
+	var params importJobParams
+	if err := json.Unmarshal([]byte(job.params), &params); err != nil {
+		return nil, fmt.Errorf("paramètres du job %d illisibles: %v", job.ID, err)
+	}
+
+	result, err := a.executeJob(job, func(item JobItem) error {
+		// Les adresses saisies sont comparées sous leur forme canonique ; une carte déjà suivie,
+		// quelle que soit sa liste, est ignorée plutôt que déplacée
+		_, productURL, err := a.parseProductURL(item.Key)
+		if err != nil {
+			return err
+		}
+		if _, err := a.getCardByURL(productURL.Canonical); err == nil {
+			return errJobItemSkipped
+		}
+
+		_, err = a.AddCard(AddCardRequest{
+			URL:      productURL.Canonical,
+			Type:     params.CardType,
+			Quality:  params.Criteria.Quality,
+			Language: params.Criteria.Language,
+			Edition:  params.Criteria.Edition,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+
+	// Le résumé couvre tout le job, y compris les éléments traités avant une reprise
+	final, err := a.GetJob(job.ID)
+	if err != nil {
+		return nil, err
+	}
+	errorDetails := []string{}
+	for _, item := range final.Items {
+		if item.Status == "failed" {
+			errorDetails = append(errorDetails, fmt.Sprintf("%s: %s", item.Label, item.Error))
+		}
+	}
+
+	summary := &ImportSummary{
+		JobID:        job.ID,
+		Game:         params.Game,
+		Expansion:    params.Expansion,
+		Total:        final.Total,
+		Added:        final.Done,
+		Skipped:      final.Skipped,
+		Failed:       final.Failed,
+		ErrorDetails: errorDetails,
+		Paused:       result.Paused,
+		PausedReason: result.PausedReason,
+		Remaining:    final.Pending,
+	}
+
+	log.Printf("🎉 Import terminé: %d ajoutées, %d ignorées, %d échecs sur %d produits",
+		summary.Added, summary.Skipped, summary.Failed, summary.Total)
+
+	return summary, nil
+}
+
+// scrapeExpansionProducts parcourt toutes les pages de la liste des cartes d'une extension
+func (a *App) scrapeExpansionProducts(listingURL string) ([]SearchCandidate, error) {
+	if delay := a.backoff.Delay(); delay > 0 {
+		log.Printf("⏳ Backoff anti-bot: attente de %v avant lecture de l'extension", delay.Round(time.Second))
+		time.Sleep(delay)
+	}
+
+	tab, err := a.browsers.Acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := a.scrapeExpansionProductsInTab(tab.ctx, listingURL)
+	a.browsers.Release(tab, err)
+	if err != nil {
+		if isChallengeError(err) {
+			a.backoff.Failure()
+		}
+		return nil, err
+	}
+	a.backoff.Success()
+
+	return products, nil
+}
+
+// scrapeExpansionProductsInTab suit la pagination de la liste en ignorant les doublons.
+// Chaque page a son propre délai : une grande extension peut compter des dizaines de pages.
+func (a *App) scrapeExpansionProductsInTab(ctx context.Context, listingURL string) ([]SearchCandidate, error) {
+	var products []SearchCandidate
+	seen := map[string]bool{}
+	visited := map[string]bool{}
+
+	pageURL := listingURL
+	for page := 1; pageURL != "" && page <= maxExpansionPages; page++ {
+		if visited[pageURL] {
+			break
+		}
+		visited[pageURL] = true
+
+		log.Printf("📄 Page %d de l'extension: %s", page, pageURL)
+		list, err := a.readProductListPage(ctx, pageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range list.Products {
+			if !seen[product.ProductURL] {
+				seen[product.ProductURL] = true
+				products = append(products, product)
+			}
+		}
+
+		pageURL = list.NextURL
+	}
+
+	if pageURL != "" && !visited[pageURL] {
+		log.Printf("⚠️  Limite de %d pages atteinte, liste de l'extension tronquée", maxExpansionPages)
+	}
+
+	if len(products) == 0 {
+		return nil, fmt.Errorf("aucune carte trouvée dans cette extension")
+	}
+
+	return products, nil
+}
+
+// readProductListPage charge et lit une page de liste de produits, dans le délai de scraping d'une page
+func (a *App) readProductListPage(ctx context.Context, pageURL string) (*productListPage, error) {
+	pageCtx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+
+	if err := a.getPage(false, pageCtx, pageURL); err != nil {
+		return nil, err
+	}
+	return a.extractProductList(pageCtx)
+}
@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+// TestImportCardsSkipsTrackedCards vérifie qu'une adresse déjà suivie sous une autre forme est ignorée,
+// sans déplacer la carte de sa liste
+func TestImportCardsSkipsTrackedCards(t *testing.T) {
+	app := newTestApp(t)
+	raw := "cardmarket.com/en/YuGiOh/Products/Singles/Legend-of-Blue-Eyes-White-Dragon/Dark-Magician-V1-Ultra-Rare?language=2&minCondition=2"
+	cardID := insertTestCard(t, app, Card{
+		Name: "Dark Magician", Price: "45,00 €", PriceNum: 45, Currency: "EUR", Type: "wishlist",
+		CardURL: canonicalCardURL(raw),
+	})
+	if canonicalCardURL(raw) == raw {
+		t.Fatalf("l'adresse de test doit différer de sa forme canonique")
+	}
+
+	summary, err := app.ImportCards([]string{raw}, "collection", CardCriteria{Quality: "NM", Language: "Français"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Skipped != 1 || summary.Added != 0 || summary.Failed != 0 {
+		t.Errorf("%d ignorée(s), %d ajoutée(s), %d échec(s), attendu 1, 0 et 0 (%v)",
+			summary.Skipped, summary.Added, summary.Failed, summary.ErrorDetails)
+	}
+
+	card, err := app.getCardByID(cardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.Type != "wishlist" {
+		t.Errorf("carte déplacée dans %q, attendu wishlist", card.Type)
+	}
+}
+
+// TestImportSummaryCoversResumedJob vérifie que le résumé d'un job repris compte aussi les éléments déjà traités
+func TestImportSummaryCoversResumedJob(t *testing.T) {
+	app := newTestApp(t)
+	job, err := app.createJob("import", "Import de 2 carte(s)", importJobParams{CardType: "collection"}, []JobItem{
+		{Key: "https://www.cardmarket.com/fr/YuGiOh/Products/Singles/Legend-of-Blue-Eyes-White-Dragon/Dark-Magician-V1-Ultra-Rare", Label: "Dark Magician"},
+		{Key: "https://example.com/carte", Label: "Adresse invalide"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Premier élément traité avant l'interruption de l'application
+	if _, err := app.db.Exec("UPDATE job_items SET status = 'skipped' WHERE job_id = ? AND position = 0", job.ID); err != nil {
+		t.Fatal(err)
+	}
+	app.markInterruptedJobs()
+	resumed, err := app.claimJob(job.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := app.runImportJob(resumed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Total != 2 || summary.Skipped != 1 || summary.Failed != 1 || summary.Added != 0 || summary.Remaining != 0 {
+		t.Errorf("%d/%d/%d sur %d (%d restante(s)), attendu 0 ajoutée, 1 ignorée, 1 échec sur 2",
+			summary.Added, summary.Skipped, summary.Failed, summary.Total, summary.Remaining)
+	}
+	if len(summary.ErrorDetails) != 1 {
+		t.Errorf("erreurs %v, attendu celle de l'adresse invalide", summary.ErrorDetails)
+	}
+}
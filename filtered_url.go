@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Identifiants de langue utilisés par les filtres CardMarket (?language=)
+var cardmarketLanguageIDs = map[string]int{
+	"English":   1,
+	"Français":  2,
+	"Deutsch":   3,
+	"Español":   4,
+	"Italiano":  5,
+	"S-Chinese": 6,
+	"日本語":       7,
+	"Português": 8,
+	"Русский":   9,
+	"한국어":       10,
+	"T-Chinese": 11,
+}
+
+// Libellés de langue correspondant aux identifiants CardMarket, les mêmes que dans cardmarketLanguageIDs
+var cardmarketLanguageNames = map[int]string{
+	1:  "English",
+	2:  "Français",
+	3:  "Deutsch",
+	4:  "Español",
+	5:  "Italiano",
+	6:  "S-Chinese",
+	7:  "日本語",
+	8:  "Português",
+	9:  "Русский",
+	10: "한국어",
+	11: "T-Chinese",
+}
+
+// Identifiants d'état utilisés par les filtres CardMarket (?minCondition=)
+var cardmarketConditionIDs = map[string]int{
+	"MT": 1,
+	"NM": 2,
+	"EX": 3,
+	"GD": 4,
+	"LP": 5,
+	"PL": 6,
+	"PO": 7,
+}
+
+// buildFilteredURL ajoute à l'URL produit les filtres CardMarket correspondant aux critères.
+// Retourne false si aucun critère ne peut être traduit en filtre.
+func buildFilteredURL(productURL, game string, req AddCardRequest) (string, bool) {
+	parsed, err := url.Parse(productURL)
+	if err != nil {
+		return productURL, false
+	}
+
+	query := parsed.Query()
+	filtered := false
+
+	if id, ok := cardmarketLanguageIDs[req.Language]; ok {
+		query.Set("language", strconv.Itoa(id))
+		filtered = true
+	}
+
+	// minCondition inclut les états supérieurs, le filtre exact reste fait par findTheCard
+	if id, ok := cardmarketConditionIDs[req.Quality]; ok {
+		query.Set("minCondition", strconv.Itoa(id))
+		filtered = true
+	}
+
+	// L'attribut spécial dépend du jeu : première édition, foil ou reverse holo
+	if param := editionFilterParam(game); req.Edition && param != "" {
+		query.Set(param, "Y")
+		filtered = true
+	}
+
+	if !filtered {
+		return productURL, false
+	}
+
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), true
+}
@@ -0,0 +1,15 @@
+package main
+
+import "testing"
+
+// TestCardmarketLanguagesRoundTrip vérifie que chaque langue garde le même libellé dans les deux sens
+func TestCardmarketLanguagesRoundTrip(t *testing.T) {
+	if len(cardmarketLanguageIDs) != len(cardmarketLanguageNames) {
+		t.Errorf("%d libellés pour %d identifiants", len(cardmarketLanguageIDs), len(cardmarketLanguageNames))
+	}
+	for name, id := range cardmarketLanguageIDs {
+		if cardmarketLanguageNames[id] != name {
+			t.Errorf("%s -> %d -> %s", name, id, cardmarketLanguageNames[id])
+		}
+	}
+}
@@ -1,10 +1,12 @@
 import { useEffect, useState } from 'react';
-import { AddCard, DeleteCard, GetCards, MoveCard, RescrapAllCards, Sumprice } from '../wailsjs/go/main/App';
+import { AddCard, AddTag, CreateList, DeleteCard, DeleteList, GetCurrencySettings, GetGames, GetJobs, GetLists, GetRescrapeRun, GetRescrapeRuns, GetStatsReport, GetTags, ImportCards, ImportExchangeRates, ImportExpansion, MoveCard, PruneImageCache, QueryCards, RefreshCard, RefreshImage, RemoveTag, RescrapAllCards, ResumeJob, RetryFailedJobItems, SearchCards, SearchCollection, SetCardNote, SetDisplayCurrency, SetExchangeRate, Sumprice, UpdateCardCriteria } from '../wailsjs/go/main/App';
+import { EventsOn } from '../wailsjs/runtime/runtime';
 
 function App() {
     const [activeTab, setActiveTab] = useState('collection');
-    const [collectionCards, setCollectionCards] = useState([]);
-    const [wishlistCards, setWishlistCards] = useState([]);
+    const [cards, setCards] = useState([]);
+    const [lists, setLists] = useState([]);
+    const [newListName, setNewListName] = useState('');
     const [newCardUrl, setNewCardUrl] = useState('');
     const [loading, setLoading] = useState(false);
     const [error, setError] = useState('');
@@ -19,9 +21,38 @@ function App() {
     const [totalPrice, setTotalPrice] = useState(0);
     const [rescrapLoading, setRescrapLoading] = useState(false);
     const [rescrapResults, setRescrapResults] = useState(null);
+    const [rescrapeRuns, setRescrapeRuns] = useState(null);
+    const [searchQuery, setSearchQuery] = useState('');
+    const [searchResults, setSearchResults] = useState([]);
+    const [searchLoading, setSearchLoading] = useState(false);
+    const [expansionUrl, setExpansionUrl] = useState('');
+    const [bulkUrls, setBulkUrls] = useState('');
+    const [jobs, setJobs] = useState([]);
+    const [showJobs, setShowJobs] = useState(false);
+    const [importLoading, setImportLoading] = useState(false);
+    const [importSummary, setImportSummary] = useState(null);
+    const [jobProgress, setJobProgress] = useState(null);
+    const [games, setGames] = useState([]);
+    const [selectedGame, setSelectedGame] = useState('');
+    const [currencySettings, setCurrencySettings] = useState({ display_currency: 'EUR', currencies: [], rates: [] });
+    const [showRates, setShowRates] = useState(false);
+    const [statsReport, setStatsReport] = useState(null);
+    const [pruneResult, setPruneResult] = useState(null);
+    const [refreshingCard, setRefreshingCard] = useState(null);
+    const [cardRefresh, setCardRefresh] = useState(null);
+    const [editingCriteria, setEditingCriteria] = useState(null);
+    const [rateInput, setRateInput] = useState({ currency: 'USD', rate: '' });
+    const [tags, setTags] = useState([]);
+    const [selectedTags, setSelectedTags] = useState([]);
+    const [tagInputs, setTagInputs] = useState({});
+    const [editingNote, setEditingNote] = useState(null);
+    const [cardQuery, setCardQuery] = useState({ search: '', sort_by: 'added_at', sort_desc: true, offset: 0 });
+    const [totalCards, setTotalCards] = useState(0);
+    const [collectionQuery, setCollectionQuery] = useState('');
+    const [collectionMatches, setCollectionMatches] = useState([]);
 
     // Fonction pour formater les prix avec des points comme séparateurs de milliers
-    const formatPrice = (priceNum) => {
+    const formatPrice = (priceNum, currency = currencySettings.display_currency) => {
         if (!priceNum || priceNum === 0) return 'N/A';
 
         // Convertir en nombre et formater avec des points pour les milliers
@@ -31,7 +62,8 @@ function App() {
             maximumFractionDigits: 2
         }).format(priceNum);
 
-        return formatted + ' €';
+        const info = currencySettings.currencies.find(c => c.code === (currency || 'EUR'));
+        return formatted + ' ' + (info ? info.symbol : (currency || '€'));
     };
 
     // Gérer le mode sombre
@@ -44,25 +76,71 @@ function App() {
         setDarkMode(!darkMode);
     };
 
-    const addCard = async () => {
-        if (!newCardUrl.trim()) return;
+    const searchCards = async () => {
+        if (!searchQuery.trim()) return;
+
+        setSearchLoading(true);
+        setError('');
+
+        try {
+            const results = await SearchCards(searchQuery, selectedGame || 'YuGiOh');
+            setSearchResults(results || []);
+        } catch (err) {
+            setError('Erreur lors de la recherche : ' + (err.message || err));
+        } finally {
+            setSearchLoading(false);
+        }
+    };
+
+    const searchCollection = async (query) => {
+        setCollectionQuery(query);
+        if (!query.trim()) {
+            setCollectionMatches([]);
+            return;
+        }
+        try {
+            const matches = await SearchCollection(query);
+            setCollectionMatches(matches || []);
+        } catch (err) {
+            setError('Erreur lors de la recherche : ' + (err.message || err));
+        }
+    };
+
+    // Les termes trouvés sont entourés des marqueurs \x02 et \x03 par SearchCollection
+    const renderHighlight = (text) => (text || '').split(/(\x02[^\x03]*\x03)/).map((part, i) =>
+        part.startsWith('\x02')
+            ? <mark key={i} style={{ background: 'var(--accent)', color: 'white' }}>{part.slice(1, -1)}</mark>
+            : part
+    );
+
+    const addCandidate = async (candidate) => {
+        // En cas d'échec, garder les résultats pour pouvoir en choisir un autre
+        if (await addCard(candidate.product_url)) {
+            setSearchResults([]);
+        }
+    };
+
+    const addCard = async (cardUrl = newCardUrl) => {
+        if (!cardUrl.trim()) return false;
 
         setLoading(true);
         setError('');
 
         try {
             await AddCard({
-                url: newCardUrl,
+                url: cardUrl,
                 type: activeTab,
                 quality: searchCriteria.quality,
                 language: searchCriteria.language,
-                edition: searchCriteria.edition
+                edition: editionLabel ? searchCriteria.edition : false
             });
 
             setNewCardUrl('');
             await loadCards(); // Recharger toutes les données pour mettre à jour les cartes et le prix total
+            return true;
         } catch (err) {
             setError('Erreur lors de l\'ajout de la carte : ' + (err.message || err));
+            return false;
         } finally {
             setLoading(false);
         }
@@ -86,6 +164,103 @@ function App() {
         }
     };
 
+    const refreshCard = async (cardId) => {
+        setRefreshingCard(cardId);
+        setError('');
+        try {
+            const refresh = await RefreshCard(cardId, null);
+            setCardRefresh(refresh);
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors de la mise à jour de la carte : ' + (err.message || err));
+        } finally {
+            setRefreshingCard(null);
+        }
+    };
+
+    const saveCriteria = async (rescrape) => {
+        const { id, quality, language, edition } = editingCriteria;
+        setRefreshingCard(id);
+        setError('');
+        try {
+            await UpdateCardCriteria(id, quality, language, edition, rescrape);
+            setEditingCriteria(null);
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors de la modification des critères : ' + (err.message || err));
+        } finally {
+            setRefreshingCard(null);
+        }
+    };
+
+    const addTag = async (cardId, tag) => {
+        if (!tag || !tag.trim()) return;
+        try {
+            await AddTag(cardId, tag);
+            setTagInputs({ ...tagInputs, [cardId]: '' });
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors de l\'ajout de l\'étiquette : ' + (err.message || err));
+        }
+    };
+
+    const removeTag = async (cardId, tag) => {
+        try {
+            await RemoveTag(cardId, tag);
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors du retrait de l\'étiquette : ' + (err.message || err));
+        }
+    };
+
+    const toggleTagFilter = (tag) => {
+        setSelectedTags(selectedTags.includes(tag)
+            ? selectedTags.filter(t => t !== tag)
+            : [...selectedTags, tag]);
+    };
+
+    const saveNote = async () => {
+        try {
+            await SetCardNote(editingNote.id, editingNote.note);
+            setEditingNote(null);
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors de l\'enregistrement de la note : ' + (err.message || err));
+        }
+    };
+
+    const refreshImage = async (cardId) => {
+        try {
+            await RefreshImage(cardId);
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors du téléchargement de l\'image : ' + (err.message || err));
+        }
+    };
+
+    const toggleStats = async () => {
+        if (statsReport) {
+            setStatsReport(null);
+            return;
+        }
+        try {
+            const report = await GetStatsReport({ type: activeTab, game: selectedGame, tags: selectedTags }, 5);
+            setStatsReport(report);
+        } catch (err) {
+            setError('Erreur lors du calcul des statistiques : ' + (err.message || err));
+        }
+    };
+
+    const pruneImages = async () => {
+        try {
+            const result = await PruneImageCache();
+            setError('');
+            setPruneResult(result);
+        } catch (err) {
+            setError('Erreur lors du nettoyage des images : ' + (err.message || err));
+        }
+    };
+
     const rescrapAllCards = async () => {
         setRescrapLoading(true);
         setError('');
@@ -102,15 +277,113 @@ function App() {
         }
     };
 
+    const toggleRescrapeRuns = async () => {
+        if (rescrapeRuns) {
+            setRescrapeRuns(null);
+            return;
+        }
+        try {
+            setRescrapeRuns(await GetRescrapeRuns() || []);
+        } catch (err) {
+            setError('Erreur lors du chargement des rescraps : ' + (err.message || err));
+        }
+    };
+
+    const showRescrapeRun = async (id) => {
+        try {
+            setRescrapResults(await GetRescrapeRun(id));
+        } catch (err) {
+            setError('Erreur lors du chargement du rescrap : ' + (err.message || err));
+        }
+    };
+
+    const importCards = async () => {
+        const urls = bulkUrls.split('\n').map(url => url.trim()).filter(Boolean);
+        if (urls.length === 0) return;
+
+        setImportLoading(true);
+        setError('');
+        setImportSummary(null);
+
+        try {
+            const summary = await ImportCards(urls, activeTab, {
+                quality: searchCriteria.quality,
+                language: searchCriteria.language,
+                edition: editionLabel ? searchCriteria.edition : false
+            });
+            setImportSummary(summary);
+            setBulkUrls('');
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors de l\'import : ' + (err.message || err));
+        } finally {
+            setImportLoading(false);
+            setJobProgress(null);
+        }
+    };
+
+    const loadJobs = async () => {
+        try {
+            setJobs(await GetJobs() || []);
+        } catch (err) {
+            setError('Erreur lors du chargement des jobs : ' + (err.message || err));
+        }
+    };
+
+    const continueJob = async (id, retryFailed) => {
+        setRescrapLoading(true);
+        setError('');
+        try {
+            await (retryFailed ? RetryFailedJobItems(id) : ResumeJob(id));
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors de la reprise du job : ' + (err.message || err));
+        } finally {
+            setRescrapLoading(false);
+            setJobProgress(null);
+            await loadJobs();
+        }
+    };
+
+    const importExpansion = async () => {
+        if (!expansionUrl.trim()) return;
+
+        setImportLoading(true);
+        setError('');
+        setImportSummary(null);
+
+        try {
+            const summary = await ImportExpansion(expansionUrl, activeTab, {
+                quality: searchCriteria.quality,
+                language: searchCriteria.language,
+                edition: editionLabel ? searchCriteria.edition : false
+            });
+            setImportSummary(summary);
+            setExpansionUrl('');
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors de l\'import de l\'extension : ' + (err.message || err));
+        } finally {
+            setImportLoading(false);
+            setJobProgress(null);
+        }
+    };
+
     const loadCards = async () => {
         try {
-            const [collection, wishlist, total] = await Promise.all([
-                GetCards('collection'),
-                GetCards('wishlist'),
-                Sumprice()
+            const [page, allLists, allTags, total] = await Promise.all([
+                QueryCards({ ...cardQuery, type: activeTab, game: selectedGame, tags: selectedTags, limit: pageSize }),
+                GetLists(),
+                GetTags(),
+                Sumprice().catch(err => {
+                    setError('Total indisponible : ' + (err.message || err));
+                    return 0;
+                })
             ]);
-            setCollectionCards(collection || []);
-            setWishlistCards(wishlist || []);
+            setCards(page.cards || []);
+            setTotalCards(page.total);
+            setLists(allLists || []);
+            setTags(allTags || []);
             setTotalPrice(total || 0);
         } catch (err) {
             setError('Erreur lors du chargement des cartes :', err);
@@ -119,9 +392,84 @@ function App() {
 
     useEffect(() => {
         loadCards();
+    }, [selectedGame, activeTab, selectedTags, cardQuery]);
+
+    // Revenir à la première page quand la liste ou les filtres changent
+    useEffect(() => {
+        setCardQuery(query => ({ ...query, offset: 0 }));
+    }, [selectedGame, activeTab, selectedTags]);
+
+    const createList = async () => {
+        try {
+            const list = await CreateList(newListName);
+            setNewListName('');
+            setActiveTab(list.slug);
+        } catch (err) {
+            setError('Erreur lors de la création de la liste : ' + (err.message || err));
+        }
+    };
+
+    const deleteList = async (slug) => {
+        try {
+            await DeleteList(slug, 'collection');
+            setActiveTab('collection');
+        } catch (err) {
+            setError('Erreur lors de la suppression de la liste : ' + (err.message || err));
+        }
+    };
+
+    useEffect(() => {
+        GetGames().then(list => setGames(list || []));
+        loadJobs();
+        GetCurrencySettings().then(settings => settings && setCurrencySettings(settings));
     }, []);
 
-    const currentCards = activeTab === 'collection' ? collectionCards : wishlistCards;
+    const changeDisplayCurrency = async (code) => {
+        try {
+            await SetDisplayCurrency(code);
+            setCurrencySettings({ ...currencySettings, display_currency: code });
+            setError('');
+            await loadCards();
+        } catch (err) {
+            setError('Erreur lors du changement de devise : ' + (err.message || err));
+        }
+    };
+
+    const reloadRates = async () => {
+        setCurrencySettings(await GetCurrencySettings());
+        setError('');
+        await loadCards();
+    };
+
+    const importRates = async () => {
+        try {
+            await ImportExchangeRates('');
+            await reloadRates();
+        } catch (err) {
+            setError('Erreur lors de l\'import des taux : ' + (err.message || err));
+        }
+    };
+
+    const saveRate = async () => {
+        try {
+            await SetExchangeRate(rateInput.currency, parseFloat(rateInput.rate.replace(',', '.')));
+            setRateInput({ ...rateInput, rate: '' });
+            await reloadRates();
+        } catch (err) {
+            setError('Erreur lors de l\'enregistrement du taux : ' + (err.message || err));
+        }
+    };
+
+    // Suivre l'avancement des rescraps et imports
+    useEffect(() => {
+        return EventsOn('scrape-job:progress', setJobProgress);
+    }, []);
+
+    const pageSize = 50;
+    const currentCards = cards;
+    const currentList = lists.find(list => list.slug === activeTab);
+    const gameProfile = games.find(game => game.code === (selectedGame || 'YuGiOh'));
+    const editionLabel = gameProfile ? gameProfile.edition_label : 'First Edition';
 
     return (
         <div className="app-bg font-['Nunito']">
@@ -137,7 +485,7 @@ function App() {
                         Card Collection
                     </h1>
                     <p className="text-sm" style={{ color: 'var(--text-secondary)' }}>
-                        Yu-Gi-Oh! Manager
+                        {selectedGame && gameProfile ? gameProfile.name : 'Trading Card'} Manager
                     </p>
                 </div>
             </header>
@@ -145,21 +493,44 @@ function App() {
             {/* Navigation minimaliste */}
             <nav className="nav-glass">
                 <div className="max-w-4xl mx-auto px-6 py-4">
-                    <div className="flex gap-1">
-                        <button
-                            className={`btn-secondary px-6 py-2 text-sm ${activeTab === 'collection' ? 'active' : ''
-                                }`}
-                            onClick={() => setActiveTab('collection')}
-                        >
-                            Collection
-                        </button>
-                        <button
-                            className={`btn-secondary px-6 py-2 text-sm ${activeTab === 'wishlist' ? 'active' : ''
-                                }`}
-                            onClick={() => setActiveTab('wishlist')}
+                    <div className="flex flex-wrap gap-1">
+                        {lists.map(list => (
+                            <button
+                                key={list.slug}
+                                className={`btn-secondary px-6 py-2 text-sm ${activeTab === list.slug ? 'active' : ''
+                                    }`}
+                                onClick={() => setActiveTab(list.slug)}
+                            >
+                                {list.name} <span style={{ color: 'var(--text-secondary)' }}>{list.count}</span>
+                            </button>
+                        ))}
+                        <input
+                            type="text"
+                            value={newListName}
+                            onChange={(e) => setNewListName(e.target.value)}
+                            onKeyDown={(e) => e.key === 'Enter' && newListName.trim() && createList()}
+                            placeholder="+ New list"
+                            className="input-glass px-3 py-2 text-sm w-32"
+                        />
+                        {currentList && !currentList.builtin && (
+                            <button
+                                onClick={() => deleteList(currentList.slug)}
+                                className="btn-secondary px-3 py-2 text-sm"
+                                title="Delete this list (cards move to Collection)"
+                            >
+                                Delete list
+                            </button>
+                        )}
+                        <select
+                            value={selectedGame}
+                            onChange={(e) => setSelectedGame(e.target.value)}
+                            className="ml-auto input-glass px-3 py-2 text-sm"
                         >
-                            Wishlist
-                        </button>
+                            <option value="">All games</option>
+                            {games.map(game => (
+                                <option key={game.code} value={game.code}>{game.name}</option>
+                            ))}
+                        </select>
                     </div>
                 </div>
             </nav>
@@ -175,6 +546,38 @@ function App() {
                     </div>
                 )}
 
+                {cardRefresh && (
+                    <div className="mb-6 glass p-4 rounded-2xl text-sm" style={{ color: 'var(--text-primary)' }}>
+                        <div className="flex justify-between mb-2">
+                            <h3 className="font-medium">{cardRefresh.after.name} mise à jour</h3>
+                            <button onClick={() => setCardRefresh(null)} style={{ color: 'var(--text-secondary)' }}>×</button>
+                        </div>
+                        {cardRefresh.changed.length === 0 ? (
+                            <p style={{ color: 'var(--text-secondary)' }}>Aucun changement</p>
+                        ) : (
+                            <ul className="space-y-1">
+                                {cardRefresh.changed.includes('price') && (
+                                    <li>Prix : {cardRefresh.before.price || 'N/A'} → {cardRefresh.after.price || 'N/A'}
+                                        {cardRefresh.price_delta !== 0 && ` (${cardRefresh.price_delta > 0 ? '+' : ''}${formatPrice(cardRefresh.price_delta, cardRefresh.after.currency)})`}
+                                    </li>
+                                )}
+                                {cardRefresh.changed.includes('total_offers') && (
+                                    <li>Offres : {cardRefresh.before.total_offers} → {cardRefresh.after.total_offers}</li>
+                                )}
+                                {cardRefresh.changed.includes('name') && (
+                                    <li>Nom : {cardRefresh.before.name} → {cardRefresh.after.name}</li>
+                                )}
+                                {cardRefresh.changed.includes('set_name') && (
+                                    <li>Set : {cardRefresh.before.set_name} → {cardRefresh.after.set_name}</li>
+                                )}
+                                {cardRefresh.changed.includes('rarity') && (
+                                    <li>Rareté : {cardRefresh.before.rarity} → {cardRefresh.after.rarity}</li>
+                                )}
+                            </ul>
+                        )}
+                    </div>
+                )}
+
                 {rescrapResults && (
                     <div className="mb-6 glass p-4 rounded-2xl" style={{
                         borderColor: '#10b981',
@@ -186,6 +589,92 @@ function App() {
                         {rescrapResults.errors > 0 && (
                             <p style={{ color: '#ef4444' }}>{rescrapResults.errors} erreurs</p>
                         )}
+                        {rescrapResults.paused && (
+                            <p style={{ color: '#f59e0b' }}>
+                                Rescrap mis en pause (protection anti-bot CardMarket) : {rescrapResults.remaining} cartes restantes
+                            </p>
+                        )}
+                        {rescrapResults.value_before !== undefined && (
+                            <p>
+                                Valeur : {formatPrice(rescrapResults.value_before, rescrapResults.currency)} → {formatPrice(rescrapResults.value_after, rescrapResults.currency)}
+                            </p>
+                        )}
+                        {[['gainers', 'Plus fortes hausses'], ['losers', 'Plus fortes baisses']].map(([key, label]) => (rescrapResults[key] || []).length > 0 && (
+                            <div key={key} className="mt-2 text-sm">
+                                <div className="font-medium">{label}</div>
+                                {rescrapResults[key].map(movement => (
+                                    <div key={movement.card_id} className="flex justify-between">
+                                        <span>{movement.name}</span>
+                                        <span>
+                                            {formatPrice(movement.old_price, movement.currency)} → {formatPrice(movement.new_price, movement.currency)} ({movement.change_percent > 0 ? '+' : ''}{movement.change_percent.toFixed(1)}%)
+                                        </span>
+                                    </div>
+                                ))}
+                            </div>
+                        ))}
+                    </div>
+                )}
+
+                {showJobs && (
+                    <div className="mb-6 glass p-4 rounded-2xl text-sm" style={{ color: 'var(--text-secondary)' }}>
+                        {jobs.length === 0 && <p>Aucun job enregistré</p>}
+                        {jobs.map(job => (
+                            <div key={job.id} className="flex items-center justify-between gap-2 mb-1">
+                                <span>
+                                    {job.label} · {job.status} · {job.done + job.skipped}/{job.total}
+                                    {job.failed > 0 && ` · ${job.failed} erreurs`}
+                                </span>
+                                <span className="flex gap-2">
+                                    {job.pending > 0 && job.status !== 'running' && (
+                                        <button onClick={() => continueJob(job.id, false)} disabled={rescrapLoading || importLoading} className="btn-secondary px-3 py-1 text-xs disabled:opacity-50">
+                                            Reprendre
+                                        </button>
+                                    )}
+                                    {job.failed > 0 && job.status !== 'running' && (
+                                        <button onClick={() => continueJob(job.id, true)} disabled={rescrapLoading || importLoading} className="btn-secondary px-3 py-1 text-xs disabled:opacity-50">
+                                            Relancer les échecs
+                                        </button>
+                                    )}
+                                </span>
+                            </div>
+                        ))}
+                    </div>
+                )}
+
+                {rescrapeRuns && (
+                    <div className="mb-6 glass p-4 rounded-2xl text-sm" style={{ color: 'var(--text-secondary)' }}>
+                        {rescrapeRuns.length === 0 && <p>Aucun rescrap enregistré</p>}
+                        {rescrapeRuns.map(run => (
+                            <div key={run.id} onClick={() => showRescrapeRun(run.id)} className="flex justify-between cursor-pointer">
+                                <span>{new Date(run.started_at).toLocaleString()} · {run.updated}/{run.total_cards} cartes{run.errors > 0 && ` · ${run.errors} erreurs`}</span>
+                                <span>{formatPrice(run.value_before, run.currency)} → {formatPrice(run.value_after, run.currency)}</span>
+                            </div>
+                        ))}
+                    </div>
+                )}
+
+                {importSummary && (
+                    <div className="mb-6 glass p-4 rounded-2xl" style={{
+                        borderColor: '#10b981',
+                        background: 'rgba(16, 185, 129, 0.1)',
+                        color: '#10b981'
+                    }}>
+                        <h3 className="font-medium mb-2">Import {importSummary.expansion && `de ${importSummary.expansion} `}terminé !</h3>
+                        <p>{importSummary.added} ajoutées, {importSummary.skipped} déjà suivies sur {importSummary.total} cartes</p>
+                        {importSummary.failed > 0 && (
+                            <p style={{ color: '#ef4444' }}>{importSummary.failed} erreurs</p>
+                        )}
+                        {importSummary.paused && (
+                            <p style={{ color: '#f59e0b' }}>
+                                Import mis en pause (protection anti-bot CardMarket) : {importSummary.remaining} cartes restantes
+                            </p>
+                        )}
+                    </div>
+                )}
+
+                {jobProgress && (rescrapLoading || importLoading) && (
+                    <div className="mb-6 text-center text-sm" style={{ color: 'var(--text-secondary)' }}>
+                        {jobProgress.current}/{jobProgress.total} · {jobProgress.label}
                     </div>
                 )}
 
@@ -203,6 +692,30 @@ function App() {
                     >
                         {rescrapLoading ? 'Rescrap en cours...' : '🔄 Mettre à jour toutes les cartes'}
                     </button>
+                    <button
+                        onClick={toggleRescrapeRuns}
+                        className="btn-secondary px-6 py-3 font-medium ml-2"
+                    >
+                        Historique
+                    </button>
+                    <button
+                        onClick={() => { setShowJobs(!showJobs); loadJobs(); }}
+                        className="btn-secondary px-6 py-3 font-medium ml-2"
+                    >
+                        Jobs {jobs.filter(job => job.status === 'paused' || job.status === 'interrupted').length > 0 && `(${jobs.filter(job => job.status === 'paused' || job.status === 'interrupted').length})`}
+                    </button>
+                    <button
+                        onClick={pruneImages}
+                        disabled={rescrapLoading}
+                        className="btn-secondary px-4 py-3 text-sm ml-2 disabled:opacity-50"
+                    >
+                        🧹 Nettoyer les images
+                    </button>
+                    {pruneResult && (
+                        <div className="text-xs mt-2" style={{ color: 'var(--text-secondary)' }}>
+                            {pruneResult.removed_files} image(s) supprimée(s), {Math.round(pruneResult.freed_bytes / 1024)} Ko libérés
+                        </div>
+                    )}
                 </div>
 
                 <div className="glass-strong p-8 mb-8 rounded-3xl">
@@ -210,6 +723,110 @@ function App() {
                         Add New Card
                     </h2>
 
+                    {/* Recherche par nom */}
+                    <div className="mb-6">
+                        <label className="block text-sm mb-3" style={{ color: 'var(--text-secondary)' }}>
+                            Search by name
+                        </label>
+                        <div className="flex gap-2">
+                            <input
+                                type="text"
+                                placeholder="Card name..."
+                                value={searchQuery}
+                                onChange={(e) => setSearchQuery(e.target.value)}
+                                onKeyDown={(e) => e.key === 'Enter' && searchCards()}
+                                className="flex-1 input-glass px-4 py-3"
+                                disabled={loading || searchLoading}
+                            />
+                            <button
+                                onClick={searchCards}
+                                disabled={loading || searchLoading || !searchQuery.trim()}
+                                className={`btn-secondary px-6 py-3 disabled:opacity-50 disabled:cursor-not-allowed ${searchLoading ? 'loading-minimal' : ''}`}
+                            >
+                                {searchLoading ? 'Searching...' : 'Search'}
+                            </button>
+                        </div>
+
+                        {searchResults.length > 0 && (
+                            <div className="mt-4 space-y-2 max-h-80 overflow-y-auto">
+                                {searchResults.map(candidate => (
+                                    <div key={candidate.product_url} className="card-glass p-3 flex items-center gap-4">
+                                        {candidate.image_url && (
+                                            <img
+                                                src={candidate.image_url}
+                                                alt={candidate.name}
+                                                className="w-10 h-14 object-cover rounded-lg flex-shrink-0"
+                                            />
+                                        )}
+                                        <div className="flex-1 min-w-0 text-sm">
+                                            <div className="font-medium" style={{ color: 'var(--text-primary)' }}>{candidate.name}</div>
+                                            <div style={{ color: 'var(--text-secondary)' }}>
+                                                {[candidate.set_name, candidate.rarity].filter(Boolean).join(' · ')}
+                                            </div>
+                                        </div>
+                                        <div className="text-sm" style={{ color: 'var(--accent)' }}>
+                                            {candidate.price_from_num ? formatPrice(candidate.price_from_num, candidate.currency) : (candidate.price_from || 'N/A')}
+                                        </div>
+                                        <button
+                                            onClick={() => addCandidate(candidate)}
+                                            disabled={loading}
+                                            className="btn-primary px-3 py-1 text-xs disabled:opacity-50"
+                                        >
+                                            Add
+                                        </button>
+                                    </div>
+                                ))}
+                            </div>
+                        )}
+                    </div>
+
+                    {/* Import d'une extension */}
+                    <div className="mb-6">
+                        <label className="block text-sm mb-3" style={{ color: 'var(--text-secondary)' }}>
+                            Import expansion
+                        </label>
+                        <div className="flex gap-2">
+                            <input
+                                type="url"
+                                placeholder="Enter CardMarket expansion URL..."
+                                value={expansionUrl}
+                                onChange={(e) => setExpansionUrl(e.target.value)}
+                                className="flex-1 input-glass px-4 py-3"
+                                disabled={loading || importLoading}
+                            />
+                            <button
+                                onClick={importExpansion}
+                                disabled={loading || importLoading || rescrapLoading || !expansionUrl.trim()}
+                                className={`btn-secondary px-6 py-3 disabled:opacity-50 disabled:cursor-not-allowed ${importLoading ? 'loading-minimal' : ''}`}
+                            >
+                                {importLoading ? 'Importing...' : 'Import'}
+                            </button>
+                        </div>
+                    </div>
+
+                    {/* Import d'une liste d'adresses */}
+                    <div className="mb-6">
+                        <label className="block text-sm mb-3" style={{ color: 'var(--text-secondary)' }}>
+                            Import URLs (one per line)
+                        </label>
+                        <div className="flex gap-2">
+                            <textarea
+                                rows={3}
+                                value={bulkUrls}
+                                onChange={(e) => setBulkUrls(e.target.value)}
+                                className="flex-1 input-glass px-4 py-3"
+                                disabled={loading || importLoading}
+                            />
+                            <button
+                                onClick={importCards}
+                                disabled={loading || importLoading || rescrapLoading || !bulkUrls.trim()}
+                                className={`btn-secondary px-6 py-3 disabled:opacity-50 disabled:cursor-not-allowed ${importLoading ? 'loading-minimal' : ''}`}
+                            >
+                                {importLoading ? 'Importing...' : 'Import'}
+                            </button>
+                        </div>
+                    </div>
+
                     {/* URL Input */}
                     <div className="mb-6">
                         <label className="block text-sm mb-3" style={{ color: 'var(--text-secondary)' }}>
@@ -217,7 +834,7 @@ function App() {
                         </label>
                         <input
                             type="url"
-                            placeholder="Enter CardMarket URL..."
+                            placeholder="Enter CardMarket or TCGplayer URL..."
                             value={newCardUrl}
                             onChange={(e) => setNewCardUrl(e.target.value)}
                             className="w-full input-glass px-4 py-3"
@@ -271,7 +888,7 @@ function App() {
                             </div>
 
                             {/* Edition */}
-                            <div>
+                            {editionLabel && <div>
                                 <label className="block text-xs mb-2" style={{ color: 'var(--text-secondary)' }}>
                                     Edition
                                 </label>
@@ -296,16 +913,16 @@ function App() {
                                             className="mr-2 text-blue-500 focus:ring-blue-400"
                                             disabled={loading}
                                         />
-                                        <span style={{ color: 'var(--text-primary)' }}>First Edition</span>
+                                        <span style={{ color: 'var(--text-primary)' }}>{editionLabel}</span>
                                     </label>
                                 </div>
-                            </div>
+                            </div>}
                         </div>
                     </div>
 
                     {/* Add Button */}
                     <button
-                        onClick={addCard}
+                        onClick={() => addCard()}
                         disabled={loading || !newCardUrl.trim()}
                         className={`w-full btn-primary px-6 py-3 font-medium disabled:opacity-50 disabled:cursor-not-allowed ${loading ? 'loading-minimal' : ''}`}
                     >
@@ -316,15 +933,221 @@ function App() {
                 <div>
                     <div className="flex items-center justify-between mb-6">
                         <h3 className="text-lg font-medium" style={{ color: 'var(--text-primary)' }}>
-                            {currentCards.length} {currentCards.length === 1 ? 'Card' : 'Cards'}
+                            {totalCards} {totalCards === 1 ? 'Card' : 'Cards'}
                         </h3>
                         <div className="text-right">
                             <div className="text-lg font-semibold" style={{ color: 'var(--accent)' }}>
                                 Total: {formatPrice(totalPrice)}
                             </div>
+                            <div className="flex items-center justify-end gap-2 mt-1 text-sm">
+                                <select
+                                    value={currencySettings.display_currency}
+                                    onChange={(e) => changeDisplayCurrency(e.target.value)}
+                                    className="input-glass px-2 py-1 text-sm"
+                                >
+                                    {currencySettings.currencies.map(currency => (
+                                        <option key={currency.code} value={currency.code}>{currency.code}</option>
+                                    ))}
+                                </select>
+                                <button onClick={() => setShowRates(!showRates)} className="btn-secondary px-3 py-1 text-sm">
+                                    Rates
+                                </button>
+                                <button onClick={toggleStats} className="btn-secondary px-3 py-1 text-sm">
+                                    Stats
+                                </button>
+                            </div>
+                        </div>
+                    </div>
+
+                    {statsReport && (
+                        <div className="glass rounded-2xl p-4 mb-6 text-sm" style={{ color: 'var(--text-secondary)' }}>
+                            <div className="flex justify-between mb-3" style={{ color: 'var(--text-primary)' }}>
+                                <span>{statsReport.count} cards · {formatPrice(statsReport.value)}</span>
+                                <span>Average {formatPrice(statsReport.average)}</span>
+                            </div>
+                            {statsReport.missing_rates.length > 0 && (
+                                <div className="mb-3">Excluded (no rate): {statsReport.missing_rates.join(', ')}</div>
+                            )}
+                            <div className="grid grid-cols-2 gap-4">
+                                <div>
+                                    <div className="mb-1" style={{ color: 'var(--text-primary)' }}>Most valuable</div>
+                                    {statsReport.top_cards.map(top => (
+                                        <div key={top.card.id} className="flex justify-between">
+                                            <span>{top.card.name}</span>
+                                            <span>{formatPrice(top.value)}</span>
+                                        </div>
+                                    ))}
+                                </div>
+                                <div>
+                                    <div className="mb-1" style={{ color: 'var(--text-primary)' }}>Biggest movers</div>
+                                    {statsReport.movers.map(mover => (
+                                        <div key={mover.card.id} className="flex justify-between">
+                                            <span>{mover.card.name}</span>
+                                            <span style={{ color: mover.change > 0 ? '#22c55e' : '#ef4444' }}>
+                                                {mover.change > 0 ? '+' : ''}{formatPrice(mover.change)} ({mover.change_percent.toFixed(1)}%)
+                                            </span>
+                                        </div>
+                                    ))}
+                                </div>
+                                {[['set_name', 'By set'], ['rarity', 'By rarity'], ['language', 'By language'], ['quality', 'By condition'], ['edition', 'By edition']].map(([key, label]) => (
+                                    <div key={key}>
+                                        <div className="mb-1" style={{ color: 'var(--text-primary)' }}>{label}</div>
+                                        {(statsReport.groups[key] || []).slice(0, 5).map(group => (
+                                            <div key={group.key} className="flex justify-between">
+                                                <span>{group.key || 'Unknown'} ({group.count})</span>
+                                                <span>{formatPrice(group.value)}</span>
+                                            </div>
+                                        ))}
+                                    </div>
+                                ))}
+                                <div>
+                                    <div className="mb-1" style={{ color: 'var(--text-primary)' }}>Value distribution</div>
+                                    {statsReport.distribution.map(bucket => (
+                                        <div key={bucket.min} className="flex justify-between">
+                                            <span>{bucket.max ? `${bucket.min}–${bucket.max}` : `${bucket.min}+`}</span>
+                                            <span>{bucket.count}</span>
+                                        </div>
+                                    ))}
+                                </div>
+                            </div>
                         </div>
+                    )}
+
+                    {showRates && (
+                        <div className="glass rounded-2xl p-4 mb-6 text-sm">
+                            <div className="flex items-center justify-between mb-3">
+                                <span style={{ color: 'var(--text-primary)' }}>Exchange rates (units per 1 EUR)</span>
+                                <button onClick={importRates} className="btn-secondary px-3 py-1 text-sm">
+                                    Import file...
+                                </button>
+                            </div>
+                            <div className="space-y-1 mb-3">
+                                {currencySettings.rates.map(rate => (
+                                    <div key={rate.currency} className="flex justify-between" style={{ color: 'var(--text-secondary)' }}>
+                                        <span>{rate.currency}</span>
+                                        <span>{rate.rate} {rate.source && `(${rate.source})`}</span>
+                                    </div>
+                                ))}
+                            </div>
+                            <div className="flex gap-2">
+                                <select
+                                    value={rateInput.currency}
+                                    onChange={(e) => setRateInput({ ...rateInput, currency: e.target.value })}
+                                    className="input-glass px-2 py-1 text-sm"
+                                >
+                                    {currencySettings.currencies.filter(c => c.code !== 'EUR').map(currency => (
+                                        <option key={currency.code} value={currency.code}>{currency.code}</option>
+                                    ))}
+                                </select>
+                                <input
+                                    type="text"
+                                    value={rateInput.rate}
+                                    onChange={(e) => setRateInput({ ...rateInput, rate: e.target.value })}
+                                    placeholder="Rate"
+                                    className="flex-1 input-glass px-3 py-1 text-sm"
+                                />
+                                <button onClick={saveRate} disabled={!rateInput.rate.trim()} className="btn-primary px-3 py-1 text-sm disabled:opacity-50">
+                                    Save
+                                </button>
+                            </div>
+                        </div>
+                    )}
+
+                    <div className="mb-4">
+                        <input
+                            type="text"
+                            value={collectionQuery}
+                            onChange={(e) => searchCollection(e.target.value)}
+                            placeholder="Search all lists (name, set, rarity, tags, notes)..."
+                            className="w-full input-glass px-4 py-2 text-sm"
+                        />
+                        {collectionMatches.length > 0 && (
+                            <div className="mt-2 space-y-1 max-h-80 overflow-y-auto">
+                                {collectionMatches.map(match => (
+                                    <div
+                                        key={match.card.id}
+                                        onClick={() => setActiveTab(match.card.type)}
+                                        className="card-glass p-3 text-sm cursor-pointer"
+                                    >
+                                        <div className="flex justify-between">
+                                            <span style={{ color: 'var(--text-primary)' }}>{renderHighlight(match.name_highlight)}</span>
+                                            <span style={{ color: 'var(--text-secondary)' }}>
+                                                {(lists.find(list => list.slug === match.card.type) || { name: match.card.type }).name}
+                                            </span>
+                                        </div>
+                                        {match.snippet !== match.name_highlight && (
+                                            <div className="text-xs" style={{ color: 'var(--text-secondary)' }}>{renderHighlight(match.snippet)}</div>
+                                        )}
+                                    </div>
+                                ))}
+                            </div>
+                        )}
+                    </div>
+
+                    <div className="flex flex-wrap items-center gap-2 mb-4 text-sm">
+                        <input
+                            type="text"
+                            value={cardQuery.search}
+                            onChange={(e) => setCardQuery({ ...cardQuery, search: e.target.value, offset: 0 })}
+                            placeholder="Filter by name or set..."
+                            className="flex-1 input-glass px-3 py-1 text-sm"
+                        />
+                        <select
+                            value={cardQuery.sort_by}
+                            onChange={(e) => setCardQuery({ ...cardQuery, sort_by: e.target.value, offset: 0 })}
+                            className="input-glass px-2 py-1 text-sm"
+                        >
+                            <option value="added_at">Date added</option>
+                            <option value="last_updated">Last updated</option>
+                            <option value="name">Name</option>
+                            <option value="set_name">Set</option>
+                            <option value="rarity">Rarity</option>
+                            <option value="price">Price</option>
+                        </select>
+                        <button
+                            onClick={() => setCardQuery({ ...cardQuery, sort_desc: !cardQuery.sort_desc, offset: 0 })}
+                            className="btn-secondary px-3 py-1 text-xs"
+                        >
+                            {cardQuery.sort_desc ? '↓' : '↑'}
+                        </button>
+                        {totalCards > pageSize && (
+                            <>
+                                <button
+                                    onClick={() => setCardQuery({ ...cardQuery, offset: Math.max(0, cardQuery.offset - pageSize) })}
+                                    disabled={cardQuery.offset === 0}
+                                    className="btn-secondary px-3 py-1 text-xs disabled:opacity-50"
+                                >
+                                    ←
+                                </button>
+                                <span style={{ color: 'var(--text-secondary)' }}>
+                                    {cardQuery.offset + 1}–{Math.min(cardQuery.offset + pageSize, totalCards)} / {totalCards}
+                                </span>
+                                <button
+                                    onClick={() => setCardQuery({ ...cardQuery, offset: cardQuery.offset + pageSize })}
+                                    disabled={cardQuery.offset + pageSize >= totalCards}
+                                    className="btn-secondary px-3 py-1 text-xs disabled:opacity-50"
+                                >
+                                    →
+                                </button>
+                            </>
+                        )}
                     </div>
 
+                    {tags.length > 0 && (
+                        <div className="flex flex-wrap items-center gap-2 mb-6 text-sm">
+                            <span style={{ color: 'var(--text-secondary)' }}>Tags</span>
+                            {tags.map(tag => (
+                                <button
+                                    key={tag.name}
+                                    onClick={() => toggleTagFilter(tag.name)}
+                                    className={`${selectedTags.includes(tag.name) ? 'btn-primary' : 'btn-secondary'} px-3 py-1 text-xs`}
+                                >
+                                    {tag.name} ({tag.count})
+                                </button>
+                            ))}
+                        </div>
+                    )}
+
                     {currentCards.length === 0 ? (
                         <div className="text-center py-20 glass rounded-3xl">
                             <p className="text-lg mb-2" style={{ color: 'var(--text-secondary)' }}>
@@ -339,9 +1162,9 @@ function App() {
                             {currentCards.map(card => (
                                 <div key={card.id} className="card-glass p-6 group">
                                     <div className="flex items-start gap-6">
-                                        {card.image_url && (
+                                        {(card.local_image || card.image_url) && (
                                             <img
-                                                src={card.image_url}
+                                                src={card.local_image || card.image_url}
                                                 alt={card.name}
                                                 className="w-16 h-20 object-cover rounded-xl flex-shrink-0"
                                             />
@@ -378,6 +1201,87 @@ function App() {
                                                 )}
                                             </div>
 
+                                            {/* Tags */}
+                                            <div className="flex flex-wrap items-center gap-2 mb-3 text-xs">
+                                                {(card.tags || []).map(tag => (
+                                                    <span key={tag} className="px-2 py-1 rounded-lg" style={{ background: 'var(--accent)', color: 'white' }}>
+                                                        {tag}
+                                                        <button onClick={() => removeTag(card.id, tag)} className="ml-1">×</button>
+                                                    </span>
+                                                ))}
+                                                <input
+                                                    type="text"
+                                                    list="known-tags"
+                                                    value={tagInputs[card.id] || ''}
+                                                    onChange={(e) => setTagInputs({ ...tagInputs, [card.id]: e.target.value })}
+                                                    onKeyDown={(e) => e.key === 'Enter' && addTag(card.id, tagInputs[card.id])}
+                                                    placeholder="Add tag"
+                                                    className="input-glass px-2 py-1 text-xs w-28"
+                                                />
+                                            </div>
+
+                                            {/* Note */}
+                                            {editingNote && editingNote.id === card.id ? (
+                                                <div className="flex items-center gap-2 mb-3 text-sm">
+                                                    <input
+                                                        type="text"
+                                                        value={editingNote.note}
+                                                        onChange={(e) => setEditingNote({ ...editingNote, note: e.target.value })}
+                                                        onKeyDown={(e) => e.key === 'Enter' && saveNote()}
+                                                        className="flex-1 input-glass px-3 py-1 text-sm"
+                                                    />
+                                                    <button onClick={saveNote} className="btn-primary px-3 py-1 text-xs">
+                                                        Save
+                                                    </button>
+                                                    <button onClick={() => setEditingNote(null)} className="text-xs" style={{ color: 'var(--text-secondary)' }}>
+                                                        Cancel
+                                                    </button>
+                                                </div>
+                                            ) : card.note && (
+                                                <p className="text-sm mb-3 italic" style={{ color: 'var(--text-secondary)' }}>
+                                                    {card.note}
+                                                </p>
+                                            )}
+
+                                            {/* Criteria editor */}
+                                            {editingCriteria && editingCriteria.id === card.id && (
+                                                <div className="flex flex-wrap items-center gap-2 mb-3 text-sm">
+                                                    <select
+                                                        value={editingCriteria.quality}
+                                                        onChange={(e) => setEditingCriteria({ ...editingCriteria, quality: e.target.value })}
+                                                        className="input-glass px-2 py-1 text-sm"
+                                                    >
+                                                        {['MT', 'NM', 'EX', 'GD', 'LP', 'PL', 'PO'].map(q => <option key={q} value={q}>{q}</option>)}
+                                                    </select>
+                                                    <select
+                                                        value={editingCriteria.language}
+                                                        onChange={(e) => setEditingCriteria({ ...editingCriteria, language: e.target.value })}
+                                                        className="input-glass px-2 py-1 text-sm"
+                                                    >
+                                                        {['Français', 'English', 'Deutsch', 'Español', 'Italiano', '日本語'].map(l => <option key={l} value={l}>{l}</option>)}
+                                                    </select>
+                                                    {(games.find(game => game.code === card.game) || { edition_label: 'First Edition' }).edition_label && (
+                                                        <label className="flex items-center gap-1" style={{ color: 'var(--text-primary)' }}>
+                                                            <input
+                                                                type="checkbox"
+                                                                checked={editingCriteria.edition}
+                                                                onChange={(e) => setEditingCriteria({ ...editingCriteria, edition: e.target.checked })}
+                                                            />
+                                                            {(games.find(game => game.code === card.game) || { edition_label: 'First Edition' }).edition_label}
+                                                        </label>
+                                                    )}
+                                                    <button onClick={() => saveCriteria(false)} disabled={refreshingCard === card.id} className="btn-secondary px-3 py-1 text-xs disabled:opacity-50">
+                                                        Save
+                                                    </button>
+                                                    <button onClick={() => saveCriteria(true)} disabled={refreshingCard === card.id} className="btn-primary px-3 py-1 text-xs disabled:opacity-50">
+                                                        Save & refresh
+                                                    </button>
+                                                    <button onClick={() => setEditingCriteria(null)} className="text-xs" style={{ color: 'var(--text-secondary)' }}>
+                                                        Cancel
+                                                    </button>
+                                                </div>
+                                            )}
+
                                             {/* Card link */}
                                             <a
                                                 href={card.card_url}
@@ -386,7 +1290,7 @@ function App() {
                                                 className="text-sm hover:underline transition-colors"
                                                 style={{ color: 'var(--accent)' }}
                                             >
-                                                View on CardMarket →
+                                                View on {card.marketplace === 'tcgplayer' ? 'TCGplayer' : 'CardMarket'} →
                                             </a>
                                         </div>
 
@@ -394,7 +1298,7 @@ function App() {
                                         <div className="flex flex-col items-end gap-4">
                                             <div className="text-right">
                                                 <div className="text-2xl font-semibold" style={{ color: 'var(--accent)' }}>
-                                                    {card.price_num ? formatPrice(card.price_num) : (card.price || 'N/A')}
+                                                    {card.price_num ? formatPrice(card.price_num, card.currency) : (card.price || 'N/A')}
                                                 </div>
                                                 <div className="text-xs" style={{ color: 'var(--text-secondary)' }}>
                                                     {new Date(card.added_at).toLocaleDateString()}
@@ -411,6 +1315,41 @@ function App() {
                                                         Move to Collection
                                                     </button>
                                                 )}
+                                                <select
+                                                    value=""
+                                                    onChange={(e) => e.target.value && moveCard(card.id, e.target.value)}
+                                                    className="input-glass px-2 py-1 text-xs"
+                                                >
+                                                    <option value="">Move to...</option>
+                                                    {lists.filter(list => list.slug !== activeTab).map(list => (
+                                                        <option key={list.slug} value={list.slug}>{list.name}</option>
+                                                    ))}
+                                                </select>
+                                                <button
+                                                    onClick={() => refreshCard(card.id)}
+                                                    disabled={refreshingCard === card.id}
+                                                    className={`btn-secondary px-3 py-1 text-xs disabled:opacity-50 ${refreshingCard === card.id ? 'loading-minimal' : ''}`}
+                                                >
+                                                    Refresh
+                                                </button>
+                                                <button
+                                                    onClick={() => setEditingCriteria({ id: card.id, quality: card.quality || 'NM', language: card.language || 'Français', edition: card.edition })}
+                                                    className="btn-secondary px-3 py-1 text-xs"
+                                                >
+                                                    Edit criteria
+                                                </button>
+                                                <button
+                                                    onClick={() => setEditingNote({ id: card.id, note: card.note || '' })}
+                                                    className="btn-secondary px-3 py-1 text-xs"
+                                                >
+                                                    Note
+                                                </button>
+                                                <button
+                                                    onClick={() => refreshImage(card.id)}
+                                                    className="btn-secondary px-3 py-1 text-xs"
+                                                >
+                                                    Refresh image
+                                                </button>
                                                 <button
                                                     onClick={() => removeCard(card.id)}
                                                     className="w-8 h-8 rounded-lg flex items-center justify-center transition-all hover:scale-110"
@@ -431,6 +1370,11 @@ function App() {
                     )}
                 </div>
             </main>
+            <datalist id="known-tags">
+                {['graded', 'signed', 'proxy-replace', 'sell-soon', ...tags.map(tag => tag.name)]
+                    .filter((tag, i, all) => all.indexOf(tag) === i)
+                    .map(tag => <option key={tag} value={tag} />)}
+            </datalist>
         </div>
     )
 }
@@ -4,16 +4,92 @@ import {main} from '../models';
 
 export function AddCard(arg1:main.AddCardRequest):Promise<main.Card>;
 
+export function AddTag(arg1:number,arg2:string):Promise<main.Card>;
+
+export function CreateList(arg1:string):Promise<main.CardList>;
+
 export function DeleteCard(arg1:number):Promise<void>;
 
-export function GetCards(arg1:string):Promise<Array<main.Card>>;
+export function DeleteList(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteTag(arg1:string):Promise<void>;
+
+export function GetCards(arg1:string,arg2:string,arg3:main.CardFilter):Promise<Array<main.Card>>;
+
+export function GetCriteriaChanges(arg1:number):Promise<Array<main.CriteriaChange>>;
+
+export function GetCurrencySettings():Promise<main.CurrencySettings>;
+
+export function GetExchangeRates():Promise<Array<main.ExchangeRate>>;
+
+export function GetGames():Promise<Array<main.GameProfile>>;
+
+export function GetJob(arg1:number):Promise<main.Job>;
+
+export function GetJobs():Promise<Array<main.Job>>;
+
+export function GetLists():Promise<Array<main.CardList>>;
+
+export function GetMarketplaces():Promise<Array<main.MarketplaceInfo>>;
+
+export function GetRescrapeRun(arg1:number):Promise<main.RescrapeRun>;
+
+export function GetRescrapeRuns():Promise<Array<main.RescrapeRun>>;
+
+export function GetScrapeDiagnostics():Promise<Array<main.ScrapeDiagnostic>>;
+
+export function GetScrapeSettings():Promise<main.ScrapeSettings>;
+
+export function GetSelectorProfile():Promise<main.SelectorProfile>;
+
+export function GetStats(arg1:string,arg2:main.CardFilter):Promise<Record<string, any>>;
+
+export function GetStatsReport(arg1:main.CardQuery,arg2:number):Promise<main.StatsReport>;
 
-export function GetStats():Promise<Record<string, any>>;
+export function GetTags():Promise<Array<main.TagInfo>>;
+
+export function ImportCards(arg1:Array<string>,arg2:string,arg3:main.CardCriteria):Promise<main.ImportSummary>;
+
+export function ImportExchangeRates(arg1:string):Promise<Array<main.ExchangeRate>>;
+
+export function ImportExpansion(arg1:string,arg2:string,arg3:main.CardCriteria):Promise<main.ImportSummary>;
 
 export function MoveCard(arg1:number,arg2:string):Promise<void>;
 
+export function PruneImageCache():Promise<main.ImageCachePruneResult>;
+
+export function QueryCards(arg1:main.CardQuery):Promise<main.CardPage>;
+
+export function RefreshCard(arg1:number,arg2:main.RefreshOverrides):Promise<main.CardRefresh>;
+
+export function RefreshImage(arg1:number):Promise<main.Card>;
+
+export function ReloadSelectors():Promise<main.SelectorProfile>;
+
+export function RemoveTag(arg1:number,arg2:string):Promise<main.Card>;
+
+export function RenameList(arg1:string,arg2:string):Promise<void>;
+
 export function RescrapAllCards():Promise<Record<string, any>>;
 
+export function ResumeJob(arg1:number):Promise<main.Job>;
+
+export function RetryFailedJobItems(arg1:number):Promise<main.Job>;
+
+export function SearchCards(arg1:string,arg2:string):Promise<Array<main.SearchCandidate>>;
+
+export function SearchCollection(arg1:string):Promise<Array<main.CollectionMatch>>;
+
+export function SetCardNote(arg1:number,arg2:string):Promise<main.Card>;
+
+export function SetDisplayCurrency(arg1:string):Promise<void>;
+
+export function SetExchangeRate(arg1:string,arg2:number):Promise<void>;
+
 export function Sumprice():Promise<number>;
 
+export function UpdateCardCriteria(arg1:number,arg2:string,arg3:string,arg4:boolean,arg5:boolean):Promise<main.Card>;
+
 export function UpdateCardPriceFixed(arg1:number):Promise<main.Card>;
+
+export function UpdateScrapeSettings(arg1:main.ScrapeSettings):Promise<main.ScrapeSettings>;
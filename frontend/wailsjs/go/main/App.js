@@ -6,30 +6,182 @@ export function AddCard(arg1) {
   return window['go']['main']['App']['AddCard'](arg1);
 }
 
+export function AddTag(arg1,arg2) {
+  return window['go']['main']['App']['AddTag'](arg1,arg2);
+}
+
+export function CreateList(arg1) {
+  return window['go']['main']['App']['CreateList'](arg1);
+}
+
 export function DeleteCard(arg1) {
   return window['go']['main']['App']['DeleteCard'](arg1);
 }
 
-export function GetCards(arg1) {
-  return window['go']['main']['App']['GetCards'](arg1);
+export function DeleteList(arg1,arg2) {
+  return window['go']['main']['App']['DeleteList'](arg1,arg2);
+}
+
+export function DeleteTag(arg1) {
+  return window['go']['main']['App']['DeleteTag'](arg1);
+}
+
+export function GetCards(arg1,arg2,arg3) {
+  return window['go']['main']['App']['GetCards'](arg1,arg2,arg3);
+}
+
+export function GetCriteriaChanges(arg1) {
+  return window['go']['main']['App']['GetCriteriaChanges'](arg1);
+}
+
+export function GetCurrencySettings() {
+  return window['go']['main']['App']['GetCurrencySettings']();
+}
+
+export function GetExchangeRates() {
+  return window['go']['main']['App']['GetExchangeRates']();
+}
+
+export function GetGames() {
+  return window['go']['main']['App']['GetGames']();
+}
+
+export function GetJob(arg1) {
+  return window['go']['main']['App']['GetJob'](arg1);
+}
+
+export function GetJobs() {
+  return window['go']['main']['App']['GetJobs']();
+}
+
+export function GetLists() {
+  return window['go']['main']['App']['GetLists']();
+}
+
+export function GetMarketplaces() {
+  return window['go']['main']['App']['GetMarketplaces']();
+}
+
+export function GetRescrapeRun(arg1) {
+  return window['go']['main']['App']['GetRescrapeRun'](arg1);
+}
+
+export function GetRescrapeRuns() {
+  return window['go']['main']['App']['GetRescrapeRuns']();
+}
+
+export function GetScrapeDiagnostics() {
+  return window['go']['main']['App']['GetScrapeDiagnostics']();
+}
+
+export function GetScrapeSettings() {
+  return window['go']['main']['App']['GetScrapeSettings']();
+}
+
+export function GetSelectorProfile() {
+  return window['go']['main']['App']['GetSelectorProfile']();
+}
+
+export function GetStats(arg1,arg2) {
+  return window['go']['main']['App']['GetStats'](arg1,arg2);
+}
+
+export function GetStatsReport(arg1,arg2) {
+  return window['go']['main']['App']['GetStatsReport'](arg1,arg2);
 }
 
-export function GetStats() {
-  return window['go']['main']['App']['GetStats']();
+export function GetTags() {
+  return window['go']['main']['App']['GetTags']();
+}
+
+export function ImportCards(arg1,arg2,arg3) {
+  return window['go']['main']['App']['ImportCards'](arg1,arg2,arg3);
+}
+
+export function ImportExchangeRates(arg1) {
+  return window['go']['main']['App']['ImportExchangeRates'](arg1);
+}
+
+export function ImportExpansion(arg1,arg2,arg3) {
+  return window['go']['main']['App']['ImportExpansion'](arg1,arg2,arg3);
 }
 
 export function MoveCard(arg1, arg2) {
   return window['go']['main']['App']['MoveCard'](arg1, arg2);
 }
 
+export function PruneImageCache() {
+  return window['go']['main']['App']['PruneImageCache']();
+}
+
+export function QueryCards(arg1) {
+  return window['go']['main']['App']['QueryCards'](arg1);
+}
+
+export function RefreshCard(arg1,arg2) {
+  return window['go']['main']['App']['RefreshCard'](arg1,arg2);
+}
+
+export function RefreshImage(arg1) {
+  return window['go']['main']['App']['RefreshImage'](arg1);
+}
+
+export function ReloadSelectors() {
+  return window['go']['main']['App']['ReloadSelectors']();
+}
+
+export function RemoveTag(arg1,arg2) {
+  return window['go']['main']['App']['RemoveTag'](arg1,arg2);
+}
+
+export function RenameList(arg1,arg2) {
+  return window['go']['main']['App']['RenameList'](arg1,arg2);
+}
+
 export function RescrapAllCards() {
   return window['go']['main']['App']['RescrapAllCards']();
 }
 
+export function ResumeJob(arg1) {
+  return window['go']['main']['App']['ResumeJob'](arg1);
+}
+
+export function RetryFailedJobItems(arg1) {
+  return window['go']['main']['App']['RetryFailedJobItems'](arg1);
+}
+
+export function SearchCards(arg1,arg2) {
+  return window['go']['main']['App']['SearchCards'](arg1,arg2);
+}
+
+export function SearchCollection(arg1) {
+  return window['go']['main']['App']['SearchCollection'](arg1);
+}
+
+export function SetCardNote(arg1,arg2) {
+  return window['go']['main']['App']['SetCardNote'](arg1,arg2);
+}
+
+export function SetDisplayCurrency(arg1) {
+  return window['go']['main']['App']['SetDisplayCurrency'](arg1);
+}
+
+export function SetExchangeRate(arg1,arg2) {
+  return window['go']['main']['App']['SetExchangeRate'](arg1,arg2);
+}
+
 export function Sumprice() {
   return window['go']['main']['App']['Sumprice']();
 }
 
+export function UpdateCardCriteria(arg1,arg2,arg3,arg4,arg5) {
+  return window['go']['main']['App']['UpdateCardCriteria'](arg1,arg2,arg3,arg4,arg5);
+}
+
 export function UpdateCardPriceFixed(arg1) {
   return window['go']['main']['App']['UpdateCardPriceFixed'](arg1);
 }
+
+export function UpdateScrapeSettings(arg1) {
+  return window['go']['main']['App']['UpdateScrapeSettings'](arg1);
+}
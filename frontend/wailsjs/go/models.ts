@@ -27,7 +27,9 @@ export namespace main {
 	    rarity: string;
 	    price: string;
 	    price_num: number;
+	    currency: string;
 	    image_url: string;
+	    local_image: string;
 	    card_url: string;
 	    type: string;
 	    added_at: string;
@@ -36,6 +38,10 @@ export namespace main {
 	    language: string;
 	    edition: boolean;
 	    total_offers: number;
+	    game: string;
+	    marketplace: string;
+	    tags: string[];
+	    note: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Card(source);
@@ -49,7 +55,9 @@ export namespace main {
 	        this.rarity = source["rarity"];
 	        this.price = source["price"];
 	        this.price_num = source["price_num"];
+	        this.currency = source["currency"];
 	        this.image_url = source["image_url"];
+	        this.local_image = source["local_image"];
 	        this.card_url = source["card_url"];
 	        this.type = source["type"];
 	        this.added_at = source["added_at"];
@@ -58,6 +66,915 @@ export namespace main {
 	        this.language = source["language"];
 	        this.edition = source["edition"];
 	        this.total_offers = source["total_offers"];
+	        this.game = source["game"];
+	        this.marketplace = source["marketplace"];
+	        this.tags = source["tags"];
+	        this.note = source["note"];
+	    }
+	}
+	export class CardCriteria {
+	    quality: string;
+	    language: string;
+	    edition: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CardCriteria(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.quality = source["quality"];
+	        this.language = source["language"];
+	        this.edition = source["edition"];
+	    }
+	}
+	export class CardFilter {
+	    tags: string[];
+	    note: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CardFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tags = source["tags"];
+	        this.note = source["note"];
+	    }
+	}
+	export class CardList {
+	    slug: string;
+	    name: string;
+	    builtin: boolean;
+	    position: number;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CardList(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.slug = source["slug"];
+	        this.name = source["name"];
+	        this.builtin = source["builtin"];
+	        this.position = source["position"];
+	        this.count = source["count"];
+	    }
+	}
+	export class CardPage {
+	    cards: Card[];
+	    total: number;
+	    limit: number;
+	    offset: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CardPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cards = this.convertValues(source["cards"], Card);
+	        this.total = source["total"];
+	        this.limit = source["limit"];
+	        this.offset = source["offset"];
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CardQuery {
+	    tags: string[];
+	    note: string;
+	    type: string;
+	    game: string;
+	    search: string;
+	    rarity: string;
+	    language: string;
+	    quality: string;
+	    edition?: boolean;
+	    min_price?: number;
+	    max_price?: number;
+	    added_from: string;
+	    added_to: string;
+	    updated_from: string;
+	    updated_to: string;
+	    sort_by: string;
+	    sort_desc: boolean;
+	    limit: number;
+	    offset: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CardQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tags = source["tags"];
+	        this.note = source["note"];
+	        this.type = source["type"];
+	        this.game = source["game"];
+	        this.search = source["search"];
+	        this.rarity = source["rarity"];
+	        this.language = source["language"];
+	        this.quality = source["quality"];
+	        this.edition = source["edition"];
+	        this.min_price = source["min_price"];
+	        this.max_price = source["max_price"];
+	        this.added_from = source["added_from"];
+	        this.added_to = source["added_to"];
+	        this.updated_from = source["updated_from"];
+	        this.updated_to = source["updated_to"];
+	        this.sort_by = source["sort_by"];
+	        this.sort_desc = source["sort_desc"];
+	        this.limit = source["limit"];
+	        this.offset = source["offset"];
+	    }
+	}
+	export class CardRefresh {
+	    card: Card;
+	    before: CardSnapshot;
+	    after: CardSnapshot;
+	    changed: string[];
+	    price_delta: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CardRefresh(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.card = this.convertValues(source["card"], Card);
+	        this.before = this.convertValues(source["before"], CardSnapshot);
+	        this.after = this.convertValues(source["after"], CardSnapshot);
+	        this.changed = source["changed"];
+	        this.price_delta = source["price_delta"];
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CardSnapshot {
+	    name: string;
+	    set_name: string;
+	    rarity: string;
+	    price: string;
+	    price_num: number;
+	    currency: string;
+	    total_offers: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CardSnapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.set_name = source["set_name"];
+	        this.rarity = source["rarity"];
+	        this.price = source["price"];
+	        this.price_num = source["price_num"];
+	        this.currency = source["currency"];
+	        this.total_offers = source["total_offers"];
+	    }
+	}
+	export class CardValue {
+	    card: Card;
+	    value: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CardValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.card = this.convertValues(source["card"], Card);
+	        this.value = source["value"];
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CollectionMatch {
+	    card: Card;
+	    rank: number;
+	    name_highlight: string;
+	    snippet: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CollectionMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.card = this.convertValues(source["card"], Card);
+	        this.rank = source["rank"];
+	        this.name_highlight = source["name_highlight"];
+	        this.snippet = source["snippet"];
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CriteriaChange {
+	    id: number;
+	    card_id: number;
+	    old_quality: string;
+	    old_language: string;
+	    old_edition: boolean;
+	    new_quality: string;
+	    new_language: string;
+	    new_edition: boolean;
+	    rescraped: boolean;
+	    changed_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CriteriaChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.card_id = source["card_id"];
+	        this.old_quality = source["old_quality"];
+	        this.old_language = source["old_language"];
+	        this.old_edition = source["old_edition"];
+	        this.new_quality = source["new_quality"];
+	        this.new_language = source["new_language"];
+	        this.new_edition = source["new_edition"];
+	        this.rescraped = source["rescraped"];
+	        this.changed_at = source["changed_at"];
+	    }
+	}
+	export class CurrencyInfo {
+	    code: string;
+	    symbol: string;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CurrencyInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	    }
+	}
+	export class CurrencySettings {
+	    display_currency: string;
+	    currencies: CurrencyInfo[];
+	    rates: ExchangeRate[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CurrencySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.display_currency = source["display_currency"];
+	        this.currencies = this.convertValues(source["currencies"], CurrencyInfo);
+	        this.rates = this.convertValues(source["rates"], ExchangeRate);
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExchangeRate {
+	    currency: string;
+	    rate: number;
+	    source: string;
+	    updated_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExchangeRate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.rate = source["rate"];
+	        this.source = source["source"];
+	        this.updated_at = source["updated_at"];
+	    }
+	}
+	export class GameProfile {
+	    code: string;
+	    name: string;
+	    edition_label: string;
+	    edition_filter: string;
+	    edition_markers: string[];
+	    rarities: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GameProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	        this.edition_label = source["edition_label"];
+	        this.edition_filter = source["edition_filter"];
+	        this.edition_markers = source["edition_markers"];
+	        this.rarities = source["rarities"];
+	    }
+	}
+	export class ImageCachePruneResult {
+	    removed_files: number;
+	    freed_bytes: number;
+	    kept_files: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageCachePruneResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.removed_files = source["removed_files"];
+	        this.freed_bytes = source["freed_bytes"];
+	        this.kept_files = source["kept_files"];
+	    }
+	}
+	export class ImportSummary {
+	    job_id: number;
+	    game: string;
+	    expansion: string;
+	    total: number;
+	    added: number;
+	    skipped: number;
+	    failed: number;
+	    error_details: string[];
+	    paused: boolean;
+	    paused_reason: string;
+	    remaining: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.job_id = source["job_id"];
+	        this.game = source["game"];
+	        this.expansion = source["expansion"];
+	        this.total = source["total"];
+	        this.added = source["added"];
+	        this.skipped = source["skipped"];
+	        this.failed = source["failed"];
+	        this.error_details = source["error_details"];
+	        this.paused = source["paused"];
+	        this.paused_reason = source["paused_reason"];
+	        this.remaining = source["remaining"];
+	    }
+	}
+	export class Job {
+	    id: number;
+	    kind: string;
+	    label: string;
+	    status: string;
+	    paused_reason: string;
+	    total: number;
+	    pending: number;
+	    done: number;
+	    skipped: number;
+	    failed: number;
+	    created_at: string;
+	    updated_at: string;
+	    items?: JobItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Job(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.label = source["label"];
+	        this.status = source["status"];
+	        this.paused_reason = source["paused_reason"];
+	        this.total = source["total"];
+	        this.pending = source["pending"];
+	        this.done = source["done"];
+	        this.skipped = source["skipped"];
+	        this.failed = source["failed"];
+	        this.created_at = source["created_at"];
+	        this.updated_at = source["updated_at"];
+	        this.items = this.convertValues(source["items"], JobItem);
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JobItem {
+	    id: number;
+	    position: number;
+	    key: string;
+	    label: string;
+	    status: string;
+	    error: string;
+	    attempts: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new JobItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.position = source["position"];
+	        this.key = source["key"];
+	        this.label = source["label"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.attempts = source["attempts"];
+	    }
+	}
+	export class ListStats {
+	    slug: string;
+	    name: string;
+	    count: number;
+	    value: number;
+	    missing_rates: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ListStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.slug = source["slug"];
+	        this.name = source["name"];
+	        this.count = source["count"];
+	        this.value = source["value"];
+	        this.missing_rates = source["missing_rates"];
+	    }
+	}
+	export class MarketplaceInfo {
+	    id: string;
+	    name: string;
+	    currency: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketplaceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.currency = source["currency"];
+	    }
+	}
+	export class PriceMovement {
+	    card_id: number;
+	    name: string;
+	    currency: string;
+	    old_price: number;
+	    new_price: number;
+	    change: number;
+	    change_percent: number;
+	    value_change: number;
+	    old_offers: number;
+	    new_offers: number;
+	    offers_change: number;
+	    status: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PriceMovement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.card_id = source["card_id"];
+	        this.name = source["name"];
+	        this.currency = source["currency"];
+	        this.old_price = source["old_price"];
+	        this.new_price = source["new_price"];
+	        this.change = source["change"];
+	        this.change_percent = source["change_percent"];
+	        this.value_change = source["value_change"];
+	        this.old_offers = source["old_offers"];
+	        this.new_offers = source["new_offers"];
+	        this.offers_change = source["offers_change"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	    }
+	}
+	export class PriceMover {
+	    card: Card;
+	    previous: number;
+	    current: number;
+	    change: number;
+	    change_percent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PriceMover(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.card = this.convertValues(source["card"], Card);
+	        this.previous = source["previous"];
+	        this.current = source["current"];
+	        this.change = source["change"];
+	        this.change_percent = source["change_percent"];
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RefreshOverrides {
+	    quality: string;
+	    language: string;
+	    edition?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RefreshOverrides(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.quality = source["quality"];
+	        this.language = source["language"];
+	        this.edition = source["edition"];
+	    }
+	}
+	export class RescrapeRun {
+	    id: number;
+	    started_at: string;
+	    finished_at: string;
+	    currency: string;
+	    total_cards: number;
+	    updated: number;
+	    errors: number;
+	    paused: boolean;
+	    value_before: number;
+	    value_after: number;
+	    value_change: number;
+	    gainers?: PriceMovement[];
+	    losers?: PriceMovement[];
+	    movements?: PriceMovement[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RescrapeRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.started_at = source["started_at"];
+	        this.finished_at = source["finished_at"];
+	        this.currency = source["currency"];
+	        this.total_cards = source["total_cards"];
+	        this.updated = source["updated"];
+	        this.errors = source["errors"];
+	        this.paused = source["paused"];
+	        this.value_before = source["value_before"];
+	        this.value_after = source["value_after"];
+	        this.value_change = source["value_change"];
+	        this.gainers = this.convertValues(source["gainers"], PriceMovement);
+	        this.losers = this.convertValues(source["losers"], PriceMovement);
+	        this.movements = this.convertValues(source["movements"], PriceMovement);
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScrapeDiagnostic {
+	    id: number;
+	    card_url: string;
+	    kind: string;
+	    reason: string;
+	    html_path: string;
+	    screenshot_path: string;
+	    selector_version: number;
+	    created_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScrapeDiagnostic(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.card_url = source["card_url"];
+	        this.kind = source["kind"];
+	        this.reason = source["reason"];
+	        this.html_path = source["html_path"];
+	        this.screenshot_path = source["screenshot_path"];
+	        this.selector_version = source["selector_version"];
+	        this.created_at = source["created_at"];
+	    }
+	}
+	export class ScrapeSettings {
+	    max_offer_rows: number;
+	    max_load_seconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScrapeSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.max_offer_rows = source["max_offer_rows"];
+	        this.max_load_seconds = source["max_load_seconds"];
+	    }
+	}
+	export class SearchCandidate {
+	    name: string;
+	    set_name: string;
+	    rarity: string;
+	    image_url: string;
+	    product_url: string;
+	    price_from: string;
+	    price_from_num: number;
+	    currency: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchCandidate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.set_name = source["set_name"];
+	        this.rarity = source["rarity"];
+	        this.image_url = source["image_url"];
+	        this.product_url = source["product_url"];
+	        this.price_from = source["price_from"];
+	        this.price_from_num = source["price_from_num"];
+	        this.currency = source["currency"];
+	    }
+	}
+	export class SelectorProfile {
+	    version: number;
+	    updated_at: string;
+	    product_title: string;
+	    info_container: string;
+	    rarity: string;
+	    rarity_attribute: string;
+	    set_link: string;
+	    offer_row: string;
+	    offer_condition: string;
+	    offer_language: string;
+	    offer_first_edition: string;
+	    offer_price: string;
+	    load_more_button: string;
+	    product_list_row: string;
+	    product_list_link: string;
+	    product_list_set: string;
+	    product_list_image: string;
+	    product_list_price: string;
+	    product_list_next_page: string;
+	    empty_offers_markers: string[];
+	    empty_results_markers: string[];
+	    cookie_banner: string[];
+	    source: string;
+	    warning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SelectorProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.updated_at = source["updated_at"];
+	        this.product_title = source["product_title"];
+	        this.info_container = source["info_container"];
+	        this.rarity = source["rarity"];
+	        this.rarity_attribute = source["rarity_attribute"];
+	        this.set_link = source["set_link"];
+	        this.offer_row = source["offer_row"];
+	        this.offer_condition = source["offer_condition"];
+	        this.offer_language = source["offer_language"];
+	        this.offer_first_edition = source["offer_first_edition"];
+	        this.offer_price = source["offer_price"];
+	        this.load_more_button = source["load_more_button"];
+	        this.product_list_row = source["product_list_row"];
+	        this.product_list_link = source["product_list_link"];
+	        this.product_list_set = source["product_list_set"];
+	        this.product_list_image = source["product_list_image"];
+	        this.product_list_price = source["product_list_price"];
+	        this.product_list_next_page = source["product_list_next_page"];
+	        this.empty_offers_markers = source["empty_offers_markers"];
+	        this.empty_results_markers = source["empty_results_markers"];
+	        this.cookie_banner = source["cookie_banner"];
+	        this.source = source["source"];
+	        this.warning = source["warning"];
+	    }
+	}
+	export class StatsGroup {
+	    key: string;
+	    count: number;
+	    value: number;
+	    average: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatsGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.count = source["count"];
+	        this.value = source["value"];
+	        this.average = source["average"];
+	    }
+	}
+	export class StatsReport {
+	    currency: string;
+	    count: number;
+	    priced_count: number;
+	    value: number;
+	    average: number;
+	    groups: {[key: string]: StatsGroup[]};
+	    top_cards: CardValue[];
+	    movers: PriceMover[];
+	    distribution: ValueBucket[];
+	    missing_rates: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StatsReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.count = source["count"];
+	        this.priced_count = source["priced_count"];
+	        this.value = source["value"];
+	        this.average = source["average"];
+	        this.groups = this.convertValues(source["groups"], Array<StatsGroup>, true);
+	        this.top_cards = this.convertValues(source["top_cards"], CardValue);
+	        this.movers = this.convertValues(source["movers"], PriceMover);
+	        this.distribution = this.convertValues(source["distribution"], ValueBucket);
+	        this.missing_rates = source["missing_rates"];
+	    }
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TagInfo {
+	    name: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TagInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.count = source["count"];
+	    }
+	}
+	export class ValueBucket {
+	    min: number;
+	    max: number;
+	    count: number;
+	    value: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ValueBucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.count = source["count"];
+	        this.value = source["value"];
 	    }
 	}
 
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Marqueurs entourant les termes trouvés dans CollectionMatch.Snippet et CollectionMatch.NameHighlight
+const (
+	highlightStart = "\x02"
+	highlightEnd   = "\x03"
+)
+
+// Nombre maximal de résultats de SearchCollection
+const maxCollectionMatches = 100
+
+// Index plein texte des cartes, une ligne par carte (rowid = cards.id), tenu à jour par des triggers.
+// Nécessite SQLite compilé avec FTS5 (tag de build sqlite_fts5).
+var createFullTextIndex = `
+CREATE VIRTUAL TABLE IF NOT EXISTS cards_fts USING fts5(
+	name, set_name, rarity, tags, notes,
+	tokenize = 'unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_insert AFTER INSERT ON cards BEGIN
+	INSERT INTO cards_fts (rowid, name, set_name, rarity, tags, notes)
+	VALUES (new.id, new.name, COALESCE(new.set_name, ''), COALESCE(new.rarity, ''), '', '');
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_update AFTER UPDATE OF name, set_name, rarity ON cards BEGIN
+	UPDATE cards_fts SET name = new.name, set_name = COALESCE(new.set_name, ''), rarity = COALESCE(new.rarity, '')
+	WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_delete AFTER DELETE ON cards BEGIN
+	DELETE FROM cards_fts WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_tag_insert AFTER INSERT ON card_tags BEGIN
+	UPDATE cards_fts SET tags = ` + ftsCardTags("new.card_id") + ` WHERE rowid = new.card_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_tag_delete AFTER DELETE ON card_tags BEGIN
+	UPDATE cards_fts SET tags = ` + ftsCardTags("old.card_id") + ` WHERE rowid = old.card_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_note_insert AFTER INSERT ON card_notes BEGIN
+	UPDATE cards_fts SET notes = new.note WHERE rowid = new.card_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_note_update AFTER UPDATE OF note ON card_notes BEGIN
+	UPDATE cards_fts SET notes = new.note WHERE rowid = new.card_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_note_delete AFTER DELETE ON card_notes BEGIN
+	UPDATE cards_fts SET notes = '' WHERE rowid = old.card_id;
+END;
+`
+
+// Triggers de createFullTextIndex : sans FTS5, ils feraient échouer toute écriture sur les cartes
+var fullTextTriggers = []string{
+	"cards_fts_insert", "cards_fts_update", "cards_fts_delete",
+	"cards_fts_tag_insert", "cards_fts_tag_delete",
+	"cards_fts_note_insert", "cards_fts_note_update", "cards_fts_note_delete",
+}
+
+// ftsCardTags retourne l'expression SQL des étiquettes d'une carte séparées par des espaces, triées
+// pour que l'index et sa vérification produisent la même valeur
+func ftsCardTags(cardID string) string {
+	return `COALESCE((SELECT GROUP_CONCAT(name, ' ') FROM (SELECT t.name FROM card_tags ct JOIN tags t ON t.id = ct.tag_id WHERE ct.card_id = ` +
+		cardID + ` ORDER BY t.name)), '')`
+}
+
+// CollectionMatch est une carte trouvée par SearchCollection
+type CollectionMatch struct {
+	Card          Card    `json:"card"`
+	Rank          float64 `json:"rank"`           // Score de pertinence, plus petit = plus pertinent
+	NameHighlight string  `json:"name_highlight"` // Nom avec les termes trouvés entre marqueurs \x02 et \x03
+	Snippet       string  `json:"snippet"`        // Extrait de la colonne la plus pertinente, mêmes marqueurs
+}
+
+// initFullTextIndex crée l'index plein texte et le reconstruit s'il n'est pas à jour.
+// Retourne false si SQLite n'a pas été compilé avec FTS5 : la recherche se fait alors par LIKE.
+func (a *App) initFullTextIndex() bool {
+	var available bool
+	a.db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available)
+	if !available {
+		log.Printf("⚠️  Index plein texte indisponible (compiler avec -tags sqlite_fts5), recherche simple utilisée")
+		a.dropFullTextTriggers()
+		return false
+	}
+	if _, err := a.db.Exec(createFullTextIndex); err != nil {
+		log.Printf("⚠️  Index plein texte indisponible, recherche simple utilisée: %v", err)
+		a.dropFullTextTriggers()
+		return false
+	}
+
+	// Les cartes modifiées par une version sans FTS5 ne sont plus à jour dans l'index
+	stale, err := a.staleFullTextRows()
+	if err != nil || stale > 0 {
+		if err := a.rebuildFullTextIndex(); err != nil {
+			log.Printf("⚠️  Reconstruction de l'index plein texte impossible: %v", err)
+			return false
+		}
+	}
+	return true
+}
+
+// dropFullTextTriggers supprime les triggers de l'index plein texte créés par une version compilée avec FTS5.
+// La table cards_fts ne peut pas être supprimée sans FTS5 : elle est conservée et reconstruite plus tard.
+func (a *App) dropFullTextTriggers() {
+	for _, trigger := range fullTextTriggers {
+		if _, err := a.db.Exec("DROP TRIGGER IF EXISTS " + trigger); err != nil {
+			log.Printf("⚠️  Suppression du trigger %s impossible: %v", trigger, err)
+		}
+	}
+}
+
+// staleFullTextRows compte les cartes dont l'entrée de l'index est absente ou périmée, et les entrées orphelines
+func (a *App) staleFullTextRows() (int, error) {
+	var stale int
+	err := a.db.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM cards_fts WHERE rowid NOT IN (SELECT id FROM cards)) +
+			(SELECT COUNT(*)
+			 FROM cards c LEFT JOIN cards_fts f ON f.rowid = c.id
+			 WHERE f.rowid IS NULL
+			    OR f.name IS NOT c.name
+			    OR f.set_name IS NOT COALESCE(c.set_name, '')
+			    OR f.rarity IS NOT COALESCE(c.rarity, '')
+			    OR f.tags IS NOT ` + ftsCardTags("c.id") + `
+			    OR f.notes IS NOT COALESCE((SELECT note FROM card_notes WHERE card_id = c.id), ''))
+	`).Scan(&stale)
+	return stale, err
+}
+
+// rebuildFullTextIndex réindexe toutes les cartes
+func (a *App) rebuildFullTextIndex() error {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM cards_fts"); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		INSERT INTO cards_fts (rowid, name, set_name, rarity, tags, notes)
+		SELECT c.id, c.name, COALESCE(c.set_name, ''), COALESCE(c.rarity, ''),
+		       ` + ftsCardTags("c.id") + `,
+		       COALESCE((SELECT note FROM card_notes WHERE card_id = c.id), '')
+		FROM cards c
+	`)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("🔎 Index plein texte reconstruit")
+	return nil
+}
+
+// ftsQuery transforme une saisie libre en requête FTS5 : chaque mot est cherché tel quel
+// (les tirets de "Blue-Eyes" ne sont pas des opérateurs) et le dernier mot peut être incomplet
+func ftsQuery(input string) string {
+	words := strings.Fields(input)
+	terms := make([]string, 0, len(words))
+	for i, word := range words {
+		term := `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+		if i == len(words)-1 {
+			term += "*"
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, " ")
+}
+
+// SearchCollection cherche des cartes dans toutes les listes par nom, extension, rareté, étiquettes et note,
+// les plus pertinentes d'abord
+func (a *App) SearchCollection(query string) ([]CollectionMatch, error) {
+	matches := []CollectionMatch{}
+	if strings.TrimSpace(query) == "" {
+		return matches, nil
+	}
+	if !a.fullText {
+		return a.searchCollectionLike(query)
+	}
+
+	// Le nom compte davantage que l'extension, elle-même plus que les étiquettes, la rareté et la note
+	rows, err := a.db.Query(`
+		SELECT `+cardColumns+`, m.score, m.name_highlight, m.snippet
+		FROM (
+			SELECT rowid AS card_id,
+			       bm25(cards_fts, 10.0, 4.0, 1.0, 2.0, 1.0) AS score,
+			       highlight(cards_fts, 0, ?, ?) AS name_highlight,
+			       snippet(cards_fts, -1, ?, ?, '…', 12) AS snippet
+			FROM cards_fts
+			WHERE cards_fts MATCH ?
+			ORDER BY score
+			LIMIT ?
+		) m
+		JOIN cards ON cards.id = m.card_id
+		ORDER BY m.score
+	`, highlightStart, highlightEnd, highlightStart, highlightEnd, ftsQuery(query), maxCollectionMatches)
+	if err != nil {
+		return nil, fmt.Errorf("erreur recherche: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var match CollectionMatch
+		card, err := scanCard(extraColumns{rows, []any{&match.Rank, &match.NameHighlight, &match.Snippet}})
+		if err != nil {
+			return nil, err
+		}
+		match.Card = *card
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+// searchCollectionLike est la recherche sans index plein texte : pas de classement, le nom sert d'extrait
+func (a *App) searchCollectionLike(query string) ([]CollectionMatch, error) {
+	pattern := "%" + escapeLike(strings.TrimSpace(query)) + "%"
+	rows, err := a.db.Query(`
+		SELECT `+cardColumns+` FROM cards
+		WHERE name LIKE ?1 ESCAPE '\' OR set_name LIKE ?1 ESCAPE '\' OR rarity LIKE ?1 ESCAPE '\'
+		   OR id IN (SELECT card_id FROM card_notes WHERE note LIKE ?1 ESCAPE '\')
+		   OR id IN (SELECT ct.card_id FROM card_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name LIKE ?1 ESCAPE '\')
+		ORDER BY name COLLATE NOCASE
+		LIMIT ?2
+	`, pattern, maxCollectionMatches)
+	if err != nil {
+		return nil, fmt.Errorf("erreur recherche: %v", err)
+	}
+	defer rows.Close()
+
+	matches := []CollectionMatch{}
+	for rows.Next() {
+		card, err := scanCard(rows)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, CollectionMatch{Card: *card, NameHighlight: card.Name, Snippet: card.Name})
+	}
+	return matches, rows.Err()
+}
+
+// extraColumns lit une carte suivie de colonnes supplémentaires
+type extraColumns struct {
+	row   rowScanner
+	extra []any
+}
+
+func (e extraColumns) Scan(dest ...any) error {
+	return e.row.Scan(append(dest, e.extra...)...)
+}
@@ -0,0 +1,131 @@
+//go:build sqlite_fts5
+
+package main
+
+import "testing"
+
+// searchNames retourne les noms des cartes trouvées par SearchCollection, dans l'ordre
+func searchNames(t *testing.T, app *App, query string) []string {
+	t.Helper()
+
+	matches, err := app.SearchCollection(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match.Card.Name)
+	}
+	return names
+}
+
+func TestSearchCollectionRanking(t *testing.T) {
+	app := newTestApp(t)
+	if !app.fullText {
+		t.Fatal("index plein texte indisponible avec le tag sqlite_fts5")
+	}
+
+	noted := insertTestCard(t, app, Card{Name: "Sorcerer of Dark Magic", Set: "Invasion of Chaos", Type: "collection", CardURL: "https://www.cardmarket.com/fr/YuGiOh/Products/Singles/Test/Card-1"})
+	insertTestCard(t, app, Card{Name: "Dark Magician", Set: "Legend of Blue Eyes White Dragon", Type: "collection", CardURL: "https://www.cardmarket.com/fr/YuGiOh/Products/Singles/Test/Card-2"})
+	insertTestCard(t, app, Card{Name: "Blue-Eyes White Dragon", Set: "Legend of Blue Eyes White Dragon", Type: "collection", CardURL: "https://www.cardmarket.com/fr/YuGiOh/Products/Singles/Test/Card-3"})
+	if _, err := app.SetCardNote(noted, "à ranger avec le magician du classeur"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Le nom pèse plus que la note, et le dernier mot peut être incomplet
+	names := searchNames(t, app, "magici")
+	if len(names) != 2 || names[0] != "Dark Magician" {
+		t.Fatalf("résultats %v, attendu Dark Magician puis Sorcerer of Dark Magic", names)
+	}
+
+	matches, err := app.SearchCollection("magician")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := matches[0].NameHighlight; got != "Dark "+highlightStart+"Magician"+highlightEnd {
+		t.Errorf("nom surligné %q", got)
+	}
+	if matches[0].Rank >= matches[1].Rank {
+		t.Errorf("scores %.3f et %.3f, attendu le nom avant la note", matches[0].Rank, matches[1].Rank)
+	}
+
+	// Les tirets ne sont pas des opérateurs, les accents sont ignorés
+	if names := searchNames(t, app, "Blue-Eyes"); len(names) != 2 {
+		t.Errorf("Blue-Eyes: %v, attendu 2 cartes", names)
+	}
+	if names := searchNames(t, app, "a ranger"); len(names) != 1 || names[0] != "Sorcerer of Dark Magic" {
+		t.Errorf("a ranger: %v, attendu Sorcerer of Dark Magic", names)
+	}
+}
+
+func TestSearchCollectionIndexSync(t *testing.T) {
+	app := newTestApp(t)
+	if !app.fullText {
+		t.Fatal("index plein texte indisponible avec le tag sqlite_fts5")
+	}
+
+	// Insertion
+	cardID := insertTestCard(t, app, Card{Name: "Dark Magician", Set: "Legend of Blue Eyes White Dragon", Rarity: "Ultra Rare", Type: "collection", CardURL: "https://www.cardmarket.com/fr/YuGiOh/Products/Singles/Test/Card-4"})
+	if names := searchNames(t, app, "magician"); len(names) != 1 {
+		t.Fatalf("après ajout: %v, attendu la carte", names)
+	}
+
+	// Mise à jour de l'extension et de la rareté
+	if _, err := app.db.Exec("UPDATE cards SET set_name = ?, rarity = ? WHERE id = ?", "Starter Deck Yugi", "Secret Rare", cardID); err != nil {
+		t.Fatal(err)
+	}
+	if names := searchNames(t, app, "legend"); len(names) != 0 {
+		t.Errorf("ancienne extension encore indexée: %v", names)
+	}
+	if names := searchNames(t, app, "starter secret"); len(names) != 1 {
+		t.Errorf("nouvelle extension et rareté non indexées: %v", names)
+	}
+
+	// Étiquettes
+	if _, err := app.AddTag(cardID, "classeur-rouge"); err != nil {
+		t.Fatal(err)
+	}
+	if names := searchNames(t, app, "classeur-rouge"); len(names) != 1 {
+		t.Errorf("étiquette ajoutée non indexée: %v", names)
+	}
+	if _, err := app.RemoveTag(cardID, "classeur-rouge"); err != nil {
+		t.Fatal(err)
+	}
+	if names := searchNames(t, app, "classeur-rouge"); len(names) != 0 {
+		t.Errorf("étiquette retirée encore indexée: %v", names)
+	}
+
+	// Note : création, modification puis suppression
+	if _, err := app.SetCardNote(cardID, "échange prévu"); err != nil {
+		t.Fatal(err)
+	}
+	if names := searchNames(t, app, "echange"); len(names) != 1 {
+		t.Errorf("note ajoutée non indexée: %v", names)
+	}
+	if _, err := app.SetCardNote(cardID, "vendue en brocante"); err != nil {
+		t.Fatal(err)
+	}
+	if names := searchNames(t, app, "echange"); len(names) != 0 {
+		t.Errorf("ancienne note encore indexée: %v", names)
+	}
+	if names := searchNames(t, app, "brocante"); len(names) != 1 {
+		t.Errorf("note modifiée non indexée: %v", names)
+	}
+	if _, err := app.SetCardNote(cardID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if names := searchNames(t, app, "brocante"); len(names) != 0 {
+		t.Errorf("note supprimée encore indexée: %v", names)
+	}
+
+	// Suppression
+	if err := app.DeleteCard(cardID); err != nil {
+		t.Fatal(err)
+	}
+	if names := searchNames(t, app, "magician"); len(names) != 0 {
+		t.Errorf("carte supprimée encore indexée: %v", names)
+	}
+	if stale, err := app.staleFullTextRows(); err != nil || stale != 0 {
+		t.Errorf("%d entrée(s) périmée(s) dans l'index (%v)", stale, err)
+	}
+}
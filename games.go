@@ -0,0 +1,184 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// GameProfile décrit les particularités d'un jeu hébergé sur CardMarket
+type GameProfile struct {
+	Code           string   `json:"code"` // Segment d'URL CardMarket ("YuGiOh", "Magic", ...)
+	Name           string   `json:"name"`
+	EditionLabel   string   `json:"edition_label"`   // Attribut spécial suivi par Card.Edition, vide si le jeu n'en a pas
+	EditionFilter  string   `json:"edition_filter"`  // Paramètre de filtre CardMarket correspondant
+	EditionMarkers []string `json:"edition_markers"` // Libellés de l'icône spéciale d'une offre
+	Rarities       []string `json:"rarities"`
+}
+
+// Jeux reconnus, le premier est le jeu par défaut
+var supportedGames = []GameProfile{
+	{
+		Code:           "YuGiOh",
+		Name:           "Yu-Gi-Oh!",
+		EditionLabel:   "First Edition",
+		EditionFilter:  "isFirstEd",
+		EditionMarkers: []string{"première édition", "1ère édition", "first edition", "1st edition", "erste auflage", "prima edizione", "primera edición"},
+		Rarities:       []string{"Common", "Rare", "Super Rare", "Ultra Rare", "Secret Rare", "Ultimate Rare", "Ghost Rare", "Starlight Rare", "Quarter Century Secret Rare", "Collector's Rare", "Prismatic Secret Rare"},
+	},
+	{
+		Code:           "Magic",
+		Name:           "Magic: The Gathering",
+		EditionLabel:   "Foil",
+		EditionFilter:  "isFoil",
+		EditionMarkers: []string{"foil"},
+		Rarities:       []string{"Common", "Uncommon", "Rare", "Mythic", "Special", "Land", "Token"},
+	},
+	{
+		Code:           "Pokemon",
+		Name:           "Pokémon",
+		EditionLabel:   "Reverse Holo",
+		EditionFilter:  "isReverseHolo",
+		EditionMarkers: []string{"reverse holo", "reverse"},
+		Rarities:       []string{"Common", "Uncommon", "Rare", "Holo Rare", "Double Rare", "Ultra Rare", "Illustration Rare", "Special Illustration Rare", "Hyper Rare", "Secret Rare", "Promo"},
+	},
+	{
+		Code:     "OnePiece",
+		Name:     "One Piece",
+		Rarities: []string{"Common", "Uncommon", "Rare", "Super Rare", "Secret Rare", "Leader", "Special Card", "Treasure Rare", "Promo"},
+	},
+	{
+		Code:           "Lorcana",
+		Name:           "Disney Lorcana",
+		EditionLabel:   "Foil",
+		EditionFilter:  "isFoil",
+		EditionMarkers: []string{"foil"},
+		Rarities:       []string{"Common", "Uncommon", "Rare", "Super Rare", "Legendary", "Enchanted", "Promo"},
+	},
+	{
+		Code:           "FleshAndBlood",
+		Name:           "Flesh and Blood",
+		EditionLabel:   "Foil",
+		EditionFilter:  "isFoil",
+		EditionMarkers: []string{"foil"},
+		Rarities:       []string{"Common", "Rare", "Super Rare", "Majestic", "Legendary", "Fabled", "Marvel", "Token", "Promo"},
+	},
+	{
+		Code:     "Digimon",
+		Name:     "Digimon",
+		Rarities: []string{"Common", "Uncommon", "Rare", "Super Rare", "Secret Rare", "Promo"},
+	},
+}
+
+// Libellés de rareté traduits sur les pages françaises
+var rarityAliases = map[string]string{
+	"commune":     "Common",
+	"peu commune": "Uncommon",
+	"mythique":    "Mythic",
+	"terrain":     "Land",
+	"jeton":       "Token",
+	"légendaire":  "Legendary",
+}
+
+// gameProfile retourne le profil d'un jeu à partir de son code CardMarket (insensible à la casse)
+func gameProfile(code string) (GameProfile, bool) {
+	for _, game := range supportedGames {
+		if strings.EqualFold(game.Code, code) {
+			return game, true
+		}
+	}
+	return GameProfile{}, false
+}
+
+// detectGame retourne le code du jeu d'une URL produit, quel que soit le site
+func (a *App) detectGame(cardURL string) string {
+	_, productURL, err := a.parseProductURL(cardURL)
+	if err != nil {
+		return ""
+	}
+	return productURL.Game
+}
+
+// offerHasEdition indique si une offre porte l'attribut spécial suivi pour ce jeu.
+// Sans libellé lisible, la seule présence d'une icône spéciale fait foi.
+func offerHasEdition(game string, hasSpecialIcon bool, specials []string) bool {
+	profile, ok := gameProfile(game)
+	if !ok || len(specials) == 0 {
+		return hasSpecialIcon
+	}
+	if len(profile.EditionMarkers) == 0 {
+		return false
+	}
+
+	for _, special := range specials {
+		special = strings.ToLower(special)
+		for _, marker := range profile.EditionMarkers {
+			if strings.Contains(special, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// editionFilterParam retourne le paramètre de filtre CardMarket de l'attribut spécial du jeu,
+// ou une chaîne vide si le jeu n'en a pas. Les jeux inconnus gardent le filtre première édition.
+func editionFilterParam(game string) string {
+	if profile, ok := gameProfile(game); ok {
+		return profile.EditionFilter
+	}
+	return "isFirstEd"
+}
+
+// normalizeRarity ramène une rareté extraite à l'orthographe de référence du jeu
+func normalizeRarity(game, rarity string) string {
+	rarity = strings.TrimSpace(rarity)
+	if alias, ok := rarityAliases[strings.ToLower(rarity)]; ok {
+		rarity = alias
+	}
+
+	if profile, ok := gameProfile(game); ok {
+		for _, known := range profile.Rarities {
+			if strings.EqualFold(known, rarity) {
+				return known
+			}
+		}
+	}
+	return rarity
+}
+
+// GetGames retourne les jeux reconnus et leurs attributs
+func (a *App) GetGames() []GameProfile {
+	return supportedGames
+}
+
+// migrateCardGames renseigne le jeu des cartes ajoutées avant le support multi-jeux
+func (a *App) migrateCardGames() {
+	rows, err := a.db.Query("SELECT id, card_url FROM cards WHERE COALESCE(game, '') = ''")
+	if err != nil {
+		log.Printf("Erreur lors de la lecture des cartes sans jeu: %v", err)
+		return
+	}
+
+	updates := map[int]string{}
+	for rows.Next() {
+		var id int
+		var cardURL string
+		if err := rows.Scan(&id, &cardURL); err != nil {
+			continue
+		}
+		if game := a.detectGame(cardURL); game != "" {
+			updates[id] = game
+		}
+	}
+	rows.Close()
+
+	for id, game := range updates {
+		if _, err := a.db.Exec("UPDATE cards SET game = ? WHERE id = ?", game, id); err != nil {
+			log.Printf("⚠️  Jeu de la carte %d non renseigné: %v", id, err)
+		}
+	}
+
+	if len(updates) > 0 {
+		log.Printf("🎲 Jeu renseigné pour %d carte(s) existante(s)", len(updates))
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Dossier du cache local des images, un fichier par contenu distinct
+const imageCacheDir = "./image_cache"
+
+// Préfixe des chemins servis par le gestionnaire d'images de l'application
+const imageRoutePrefix = "/images/"
+
+// Taille maximale d'une image téléchargée
+const maxImageBytes = 10 << 20
+
+// Extensions des types d'images acceptés
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// Nom d'un fichier du cache : empreinte SHA-256 du contenu suivie de l'extension
+var cachedImageName = regexp.MustCompile(`^[0-9a-f]{64}\.(jpg|png|webp|gif)$`)
+
+var imageHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// ImageCachePruneResult résume le nettoyage du cache d'images
+type ImageCachePruneResult struct {
+	RemovedFiles int   `json:"removed_files"`
+	FreedBytes   int64 `json:"freed_bytes"`
+	KeptFiles    int   `json:"kept_files"`
+}
+
+// resolveImageURL rend absolue l'adresse d'une image relative à la page
+func resolveImageURL(pageURL, src string) string {
+	src = strings.TrimSpace(src)
+	if src == "" || strings.HasPrefix(src, "data:") {
+		return ""
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return src
+	}
+	ref, err := url.Parse(src)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// cacheImage télécharge une image une seule fois et retourne son chemin servi par l'application.
+// Une image déjà en cache pour cette adresse n'est pas retéléchargée, sauf si force est vrai.
+func (a *App) cacheImage(sourceURL, referer string, force bool) (string, error) {
+	if !force {
+		var fileName string
+		err := a.db.QueryRow("SELECT file_name FROM image_cache WHERE source_url = ?", sourceURL).Scan(&fileName)
+		if err == nil {
+			if _, err := os.Stat(filepath.Join(imageCacheDir, fileName)); err == nil {
+				return imageRoutePrefix + fileName, nil
+			}
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("adresse d'image invalide: %v", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	if referer != "" {
+		req.Header.Set("Referer", referer)
+	}
+
+	resp, err := imageHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("téléchargement de l'image impossible: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("téléchargement de l'image impossible: statut HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("lecture de l'image impossible: %v", err)
+	}
+	if len(data) > maxImageBytes {
+		return "", fmt.Errorf("image trop volumineuse (plus de %d Mo)", maxImageBytes>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return "", fmt.Errorf("le contenu téléchargé n'est pas une image (%s)", contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	fileName := hex.EncodeToString(sum[:]) + ext
+	path := filepath.Join(imageCacheDir, fileName)
+
+	// Même contenu, même fichier : inutile de le réécrire
+	if _, err := os.Stat(path); err != nil {
+		if err := os.MkdirAll(imageCacheDir, 0o755); err != nil {
+			return "", fmt.Errorf("création du cache d'images impossible: %v", err)
+		}
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o644); err != nil {
+			return "", fmt.Errorf("écriture de l'image impossible: %v", err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			os.Remove(tmp)
+			return "", fmt.Errorf("écriture de l'image impossible: %v", err)
+		}
+	}
+
+	_, err = a.db.Exec(`
+		INSERT INTO image_cache (source_url, file_name, content_type, size, fetched_at) VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(source_url) DO UPDATE SET file_name = excluded.file_name, content_type = excluded.content_type,
+			size = excluded.size, fetched_at = excluded.fetched_at
+	`, sourceURL, fileName, contentType, len(data))
+	if err != nil {
+		return "", fmt.Errorf("erreur sauvegarde cache d'image: %v", err)
+	}
+
+	log.Printf("🖼️  Image mise en cache: %s -> %s (%d octets)", sourceURL, fileName, len(data))
+	return imageRoutePrefix + fileName, nil
+}
+
+// cacheCardImage met en cache l'image d'une carte ; un échec n'empêche pas l'enregistrement de la carte
+func (a *App) cacheCardImage(card *Card, force bool) {
+	if card.ImageURL == "" {
+		return
+	}
+	localImage, err := a.cacheImage(card.ImageURL, card.CardURL, force)
+	if err != nil {
+		log.Printf("⚠️  Image de la carte %d non mise en cache: %v", card.ID, err)
+		return
+	}
+	if _, err := a.db.Exec("UPDATE cards SET local_image = ? WHERE id = ?", localImage, card.ID); err != nil {
+		log.Printf("⚠️  Image de la carte %d non enregistrée: %v", card.ID, err)
+		return
+	}
+	card.LocalImage = localImage
+}
+
+// RefreshImage retélécharge l'image d'une carte, en relisant la page produit si son adresse est inconnue
+func (a *App) RefreshImage(cardID int) (*Card, error) {
+	card, err := a.getCardByID(cardID)
+	if err != nil {
+		return nil, fmt.Errorf("carte %d introuvable: %v", cardID, err)
+	}
+
+	if card.ImageURL == "" {
+		cardInfo, err := a.scrapeCardInfo(card.CardURL, AddCardRequest{
+			URL:      card.CardURL,
+			Type:     card.Type,
+			Quality:  card.Quality,
+			Language: card.Language,
+			Edition:  card.Edition,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if cardInfo.ImageURL == "" {
+			return nil, fmt.Errorf("aucune image trouvée sur la page produit")
+		}
+		card.ImageURL = cardInfo.ImageURL
+		if _, err := a.db.Exec("UPDATE cards SET image_url = ? WHERE id = ?", card.ImageURL, card.ID); err != nil {
+			return nil, fmt.Errorf("erreur sauvegarde: %v", err)
+		}
+	}
+
+	localImage, err := a.cacheImage(card.ImageURL, card.CardURL, true)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.db.Exec("UPDATE cards SET local_image = ? WHERE id = ?", localImage, card.ID); err != nil {
+		return nil, fmt.Errorf("erreur sauvegarde: %v", err)
+	}
+	card.LocalImage = localImage
+	return card, nil
+}
+
+// PruneImageCache supprime les images qu'aucune carte n'utilise plus
+func (a *App) PruneImageCache() (*ImageCachePruneResult, error) {
+	rows, err := a.db.Query("SELECT DISTINCT local_image FROM cards WHERE COALESCE(local_image, '') != ''")
+	if err != nil {
+		return nil, err
+	}
+	used := map[string]bool{}
+	for rows.Next() {
+		var localImage string
+		if err := rows.Scan(&localImage); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		used[strings.TrimPrefix(localImage, imageRoutePrefix)] = true
+	}
+	rows.Close()
+
+	result := &ImageCachePruneResult{}
+	entries, err := os.ReadDir(imageCacheDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("lecture du cache d'images impossible: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || used[entry.Name()] {
+			result.KeptFiles++
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if err := os.Remove(filepath.Join(imageCacheDir, entry.Name())); err != nil {
+			log.Printf("⚠️  Suppression de %s impossible: %v", entry.Name(), err)
+			continue
+		}
+		result.RemovedFiles++
+		result.FreedBytes += info.Size()
+	}
+
+	// Oublier les adresses dont le fichier n'existe plus
+	if _, err := a.db.Exec(`
+		DELETE FROM image_cache
+		WHERE file_name NOT IN (SELECT SUBSTR(local_image, ?) FROM cards WHERE COALESCE(local_image, '') != '')
+	`, len(imageRoutePrefix)+1); err != nil {
+		return nil, fmt.Errorf("erreur nettoyage du cache d'images: %v", err)
+	}
+
+	log.Printf("🧹 Cache d'images: %d fichier(s) supprimé(s), %d octets libérés, %d conservé(s)",
+		result.RemovedFiles, result.FreedBytes, result.KeptFiles)
+	return result, nil
+}
+
+// imageHandler sert les images du cache au frontend via le serveur d'assets Wails
+type imageHandler struct{}
+
+func (h imageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, imageRoutePrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	fileName := strings.TrimPrefix(r.URL.Path, imageRoutePrefix)
+	if !cachedImageName.MatchString(fileName) {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Le nom dépend du contenu : le fichier ne change jamais
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	http.ServeFile(w, r, filepath.Join(imageCacheDir, fileName))
+}
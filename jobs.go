@@ -0,0 +1,364 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Événement Wails émis après chaque élément traité par un job de scraping
+const scrapeJobProgressEvent = "scrape-job:progress"
+
+// errJobItemSkipped indique qu'un élément a été volontairement ignoré par le job
+var errJobItemSkipped = errors.New("élément ignoré")
+
+// ScrapeJobProgress décrit l'avancement d'un job de scraping
+type ScrapeJobProgress struct {
+	Job     string `json:"job"` // Type du job : "rescrape", "import" ou "expansion_import"
+	Current int    `json:"current"`
+	Total   int    `json:"total"`
+	Label   string `json:"label"`
+	Status  string `json:"status"` // "done", "skipped", "failed" ou "retry"
+}
+
+// scrapeJobResult résume l'exécution d'un job de scraping
+type scrapeJobResult struct {
+	Done         int
+	Skipped      int
+	Failed       int
+	ErrorDetails []string
+	Paused       bool
+	PausedReason string
+	Remaining    int
+}
+
+// runScrapeJob traite les éléments un par un en respectant le backoff anti-bot :
+// un élément bloqué est réessayé, et le job est mis en pause après trop de blocages consécutifs.
+// process retourne errJobItemSkipped pour un élément ignoré ; record, si non nil, reçoit le statut final
+// de chaque élément traité (l'élément en cours lors d'une pause n'est pas considéré comme traité).
+func (a *App) runScrapeJob(job string, total int, label func(i int) string, process func(i int) error,
+	record func(i int, status string, err error)) scrapeJobResult {
+	result := scrapeJobResult{ErrorDetails: []string{}}
+
+	for i := 0; i < total; i++ {
+		log.Printf("🔄 %s %d/%d: %s", job, i+1, total, label(i))
+
+		err := process(i)
+		if err != nil && isChallengeError(err) {
+			// Blocage anti-bot : mettre le job en pause plutôt que d'échouer sur tous les éléments
+			if a.backoff.Failures() >= maxConsecutiveChallenges {
+				log.Printf("⏸️  Job %s mis en pause après %d blocages consécutifs", job, a.backoff.Failures())
+				result.Paused = true
+				result.PausedReason = err.Error()
+				result.Remaining = total - i
+				break
+			}
+
+			// Réessayer le même élément après le backoff
+			log.Printf("🛡️  %s bloqué (%v), nouvelle tentative après backoff", label(i), err)
+			a.emitJobProgress(ScrapeJobProgress{Job: job, Current: i + 1, Total: total, Label: label(i), Status: "retry"})
+			i--
+			continue
+		}
+
+		status := "done"
+		switch {
+		case errors.Is(err, errJobItemSkipped):
+			status = "skipped"
+			result.Skipped++
+		case err != nil:
+			status = "failed"
+			errorMsg := fmt.Sprintf("%s: %v", label(i), err)
+			log.Printf("❌ %s", errorMsg)
+			result.Failed++
+			result.ErrorDetails = append(result.ErrorDetails, errorMsg)
+		default:
+			result.Done++
+		}
+
+		if record != nil {
+			record(i, status, err)
+		}
+		a.emitJobProgress(ScrapeJobProgress{Job: job, Current: i + 1, Total: total, Label: label(i), Status: status})
+	}
+
+	return result
+}
+
+// emitJobProgress transmet l'avancement au frontend quand l'application est démarrée
+func (a *App) emitJobProgress(progress ScrapeJobProgress) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, scrapeJobProgressEvent, progress)
+}
+
+// Statuts d'un job enregistré
+const (
+	jobRunning     = "running"
+	jobPaused      = "paused"      // Mis en pause par la protection anti-bot
+	jobInterrupted = "interrupted" // Application fermée pendant le traitement
+	jobDone        = "done"        // Tous les éléments ont été traités, éventuellement en échec
+)
+
+// Statut d'un élément pas encore traité ; les autres statuts sont ceux de runScrapeJob
+const jobItemPending = "pending"
+
+// Job est une opération longue enregistrée en base, reprise après un redémarrage
+type Job struct {
+	ID           int       `json:"id"`
+	Kind         string    `json:"kind"` // "rescrape", "import" ou "expansion_import"
+	Label        string    `json:"label"`
+	Status       string    `json:"status"`
+	PausedReason string    `json:"paused_reason"`
+	Total        int       `json:"total"`
+	Pending      int       `json:"pending"`
+	Done         int       `json:"done"`
+	Skipped      int       `json:"skipped"`
+	Failed       int       `json:"failed"`
+	CreatedAt    string    `json:"created_at"`
+	UpdatedAt    string    `json:"updated_at"`
+	Items        []JobItem `json:"items,omitempty"`
+	params       string    // Paramètres JSON propres au type de job
+}
+
+// JobItem est un élément d'un job : une carte à rescraper ou une adresse à importer
+type JobItem struct {
+	ID       int    `json:"id"`
+	Position int    `json:"position"`
+	Key      string `json:"key"` // Identifiant de carte ou adresse produit
+	Label    string `json:"label"`
+	Status   string `json:"status"` // "pending", "done", "skipped" ou "failed"
+	Error    string `json:"error"`
+	Attempts int    `json:"attempts"`
+}
+
+// createJob enregistre un job et tous ses éléments, en attente
+func (a *App) createJob(kind, label string, params any, items []JobItem) (*Job, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := a.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("INSERT INTO jobs (kind, label, params, status) VALUES (?, ?, ?, ?)", kind, label, string(encoded), jobRunning)
+	if err != nil {
+		return nil, fmt.Errorf("erreur création du job: %v", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		_, err := tx.Exec("INSERT INTO job_items (job_id, position, item_key, label, status) VALUES (?, ?, ?, ?, ?)",
+			id, i, item.Key, item.Label, jobItemPending)
+		if err != nil {
+			return nil, fmt.Errorf("erreur création du job: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🗂️  Job %d créé: %s (%d éléments)", id, label, len(items))
+	return a.GetJob(int(id))
+}
+
+// markInterruptedJobs signale les jobs restés en cours à la fermeture de l'application
+func (a *App) markInterruptedJobs() {
+	res, err := a.db.Exec("UPDATE jobs SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE status = ?", jobInterrupted, jobRunning)
+	if err != nil {
+		log.Printf("Erreur lors de la reprise des jobs: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		log.Printf("⏸️  %d job(s) interrompu(s) à la fermeture, à reprendre avec ResumeJob", n)
+	}
+}
+
+// executeJob traite les éléments en attente d'un job et enregistre le statut de chacun dès qu'il est connu
+func (a *App) executeJob(job *Job, process func(item JobItem) error) (scrapeJobResult, error) {
+	rows, err := a.db.Query(`
+		SELECT id, position, item_key, label, status, COALESCE(error, ''), attempts
+		FROM job_items WHERE job_id = ? AND status = ? ORDER BY position
+	`, job.ID, jobItemPending)
+	if err != nil {
+		return scrapeJobResult{}, err
+	}
+	items, err := scanJobItems(rows)
+	if err != nil {
+		return scrapeJobResult{}, err
+	}
+
+	result := a.runScrapeJob(job.Kind, len(items), func(i int) string {
+		return items[i].Label
+	}, func(i int) error {
+		return process(items[i])
+	}, func(i int, status string, err error) {
+		errorMsg := ""
+		if err != nil && status == "failed" {
+			errorMsg = err.Error()
+		}
+		_, dbErr := a.db.Exec(`
+			UPDATE job_items SET status = ?, error = ?, attempts = attempts + 1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+		`, status, errorMsg, items[i].ID)
+		if dbErr != nil {
+			log.Printf("⚠️  Statut de l'élément %d du job %d non enregistré: %v", items[i].Position, job.ID, dbErr)
+		}
+	})
+
+	status := jobDone
+	if result.Paused {
+		status = jobPaused
+	}
+	_, err = a.db.Exec("UPDATE jobs SET status = ?, paused_reason = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+		status, result.PausedReason, job.ID)
+	if err != nil {
+		return result, fmt.Errorf("erreur sauvegarde du job: %v", err)
+	}
+	return result, nil
+}
+
+// claimJob passe un job à l'état en cours ; retryFailed remet d'abord ses éléments en échec en attente
+func (a *App) claimJob(id int, retryFailed bool) (*Job, error) {
+	res, err := a.db.Exec("UPDATE jobs SET status = ?, paused_reason = '', updated_at = CURRENT_TIMESTAMP WHERE id = ? AND status != ?",
+		jobRunning, id, jobRunning)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		if _, err := a.GetJob(id); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("le job %d est déjà en cours", id)
+	}
+
+	if retryFailed {
+		if _, err := a.db.Exec("UPDATE job_items SET status = ?, error = '' WHERE job_id = ? AND status = 'failed'", jobItemPending, id); err != nil {
+			return nil, err
+		}
+	}
+	return a.GetJob(id)
+}
+
+// runJob exécute les éléments en attente d'un job selon son type
+func (a *App) runJob(job *Job) error {
+	if job.Pending == 0 {
+		_, err := a.db.Exec("UPDATE jobs SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", jobDone, job.ID)
+		return err
+	}
+
+	switch job.Kind {
+	case "rescrape":
+		_, err := a.runRescrapeJob(job)
+		return err
+	case "import", "expansion_import":
+		_, err := a.runImportJob(job)
+		return err
+	default:
+		return fmt.Errorf("type de job inconnu: %s", job.Kind)
+	}
+}
+
+// ResumeJob reprend un job en pause ou interrompu là où il s'était arrêté
+func (a *App) ResumeJob(id int) (*Job, error) {
+	job, err := a.claimJob(id, false)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("▶️  Reprise du job %d: %s (%d élément(s) en attente)", job.ID, job.Label, job.Pending)
+	if err := a.runJob(job); err != nil {
+		return nil, err
+	}
+	return a.GetJob(id)
+}
+
+// RetryFailedJobItems relance uniquement les éléments en échec d'un job, ainsi que ceux encore en attente
+func (a *App) RetryFailedJobItems(id int) (*Job, error) {
+	job, err := a.claimJob(id, true)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🔁 Nouvelle tentative du job %d: %s (%d élément(s))", job.ID, job.Label, job.Pending)
+	if err := a.runJob(job); err != nil {
+		return nil, err
+	}
+	return a.GetJob(id)
+}
+
+const jobColumns = `j.id, j.kind, j.label, j.status, COALESCE(j.paused_reason, ''), j.created_at, j.updated_at, j.params,
+	COUNT(i.id),
+	COALESCE(SUM(i.status = 'pending'), 0), COALESCE(SUM(i.status = 'done'), 0),
+	COALESCE(SUM(i.status = 'skipped'), 0), COALESCE(SUM(i.status = 'failed'), 0)`
+
+func scanJob(row rowScanner) (*Job, error) {
+	var job Job
+	err := row.Scan(&job.ID, &job.Kind, &job.Label, &job.Status, &job.PausedReason, &job.CreatedAt, &job.UpdatedAt, &job.params,
+		&job.Total, &job.Pending, &job.Done, &job.Skipped, &job.Failed)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func scanJobItems(rows *sql.Rows) ([]JobItem, error) {
+	defer rows.Close()
+	items := []JobItem{}
+	for rows.Next() {
+		var item JobItem
+		if err := rows.Scan(&item.ID, &item.Position, &item.Key, &item.Label, &item.Status, &item.Error, &item.Attempts); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// GetJobs retourne les jobs enregistrés avec l'avancement de leurs éléments, du plus récent au plus ancien
+func (a *App) GetJobs() ([]Job, error) {
+	rows, err := a.db.Query(`SELECT ` + jobColumns + ` FROM jobs j LEFT JOIN job_items i ON i.job_id = j.id GROUP BY j.id ORDER BY j.id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+// GetJob retourne un job et le statut de chacun de ses éléments
+func (a *App) GetJob(id int) (*Job, error) {
+	job, err := scanJob(a.db.QueryRow(`SELECT `+jobColumns+` FROM jobs j LEFT JOIN job_items i ON i.job_id = j.id WHERE j.id = ? GROUP BY j.id`, id))
+	if err != nil {
+		return nil, fmt.Errorf("job %d introuvable: %v", id, err)
+	}
+
+	rows, err := a.db.Query(`
+		SELECT id, position, item_key, label, status, COALESCE(error, ''), attempts
+		FROM job_items WHERE job_id = ? ORDER BY position
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Items, err = scanJobItems(rows); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
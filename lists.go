@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// Listes créées d'office, qui ne peuvent pas être supprimées
+var builtinLists = []CardList{
+	{Slug: "collection", Name: "Collection", Builtin: true},
+	{Slug: "wishlist", Name: "Wishlist", Builtin: true},
+}
+
+// CardList est une liste de cartes ; cards.type contient son identifiant
+type CardList struct {
+	Slug     string `json:"slug"` // Identifiant stocké dans cards.type
+	Name     string `json:"name"`
+	Builtin  bool   `json:"builtin"`
+	Position int    `json:"position"`
+	Count    int    `json:"count"`
+}
+
+// ListStats regroupe le nombre de cartes et la valeur d'une liste
+type ListStats struct {
+	Slug         string   `json:"slug"`
+	Name         string   `json:"name"`
+	Count        int      `json:"count"`
+	Value        float64  `json:"value"`         // Dans la devise d'affichage
+	MissingRates []string `json:"missing_rates"` // Devises exclues de la valeur faute de taux
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify dérive un identifiant de liste de son nom
+func slugify(name string) string {
+	slug := strings.ToLower(strings.TrimSpace(name))
+	slug = strings.NewReplacer("à", "a", "â", "a", "ä", "a", "é", "e", "è", "e", "ê", "e", "ë", "e",
+		"î", "i", "ï", "i", "ô", "o", "ö", "o", "ù", "u", "û", "u", "ü", "u", "ç", "c").Replace(slug)
+	return strings.Trim(nonSlugChars.ReplaceAllString(slug, "-"), "-")
+}
+
+// seedBuiltinLists crée les listes d'origine et celles déjà utilisées par des cartes
+func (a *App) seedBuiltinLists() {
+	for i, list := range builtinLists {
+		_, err := a.db.Exec("INSERT OR IGNORE INTO lists (slug, name, builtin, position) VALUES (?, ?, TRUE, ?)", list.Slug, list.Name, i)
+		if err != nil {
+			log.Printf("Erreur lors de la création de la liste %s: %v", list.Slug, err)
+		}
+	}
+
+	_, err := a.db.Exec(`
+		INSERT OR IGNORE INTO lists (slug, name, builtin, position)
+		SELECT DISTINCT type, type, FALSE, 100 FROM cards WHERE type NOT IN (SELECT slug FROM lists)
+	`)
+	if err != nil {
+		log.Printf("Erreur lors de la reprise des listes existantes: %v", err)
+	}
+}
+
+// listExists indique si une liste existe
+func (a *App) listExists(slug string) bool {
+	var count int
+	err := a.db.QueryRow("SELECT COUNT(*) FROM lists WHERE slug = ?", slug).Scan(&count)
+	return err == nil && count > 0
+}
+
+// requireList retourne une erreur si la liste n'existe pas
+func (a *App) requireList(slug string) error {
+	if !a.listExists(slug) {
+		return fmt.Errorf("liste inconnue: %s", slug)
+	}
+	return nil
+}
+
+// GetLists retourne les listes avec leur nombre de cartes, dans l'ordre d'affichage
+func (a *App) GetLists() ([]CardList, error) {
+	rows, err := a.db.Query(`
+		SELECT l.slug, l.name, l.builtin, l.position, COUNT(c.id)
+		FROM lists l
+		LEFT JOIN cards c ON c.type = l.slug
+		GROUP BY l.slug
+		ORDER BY l.position, l.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := []CardList{}
+	for rows.Next() {
+		var list CardList
+		if err := rows.Scan(&list.Slug, &list.Name, &list.Builtin, &list.Position, &list.Count); err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	return lists, nil
+}
+
+// CreateList crée une liste ("Trade binder", "For sale", "Deck: Branded")
+func (a *App) CreateList(name string) (*CardList, error) {
+	name = strings.TrimSpace(name)
+	slug := slugify(name)
+	if slug == "" {
+		return nil, fmt.Errorf("nom de liste invalide: %q", name)
+	}
+
+	// Rendre l'identifiant unique sans changer le nom affiché
+	base := slug
+	for i := 2; a.listExists(slug); i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	var position int
+	a.db.QueryRow("SELECT COALESCE(MAX(position), 0) + 1 FROM lists").Scan(&position)
+
+	_, err := a.db.Exec("INSERT INTO lists (slug, name, builtin, position) VALUES (?, ?, FALSE, ?)", slug, name, position)
+	if err != nil {
+		return nil, fmt.Errorf("erreur création de la liste: %v", err)
+	}
+
+	log.Printf("📁 Liste créée: %s (%s)", name, slug)
+	return &CardList{Slug: slug, Name: name, Position: position}, nil
+}
+
+// RenameList change le nom affiché d'une liste ; son identifiant ne change pas
+func (a *App) RenameList(slug, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("le nom de la liste ne peut pas être vide")
+	}
+	if err := a.requireList(slug); err != nil {
+		return err
+	}
+	_, err := a.db.Exec("UPDATE lists SET name = ? WHERE slug = ?", name, slug)
+	return err
+}
+
+// DeleteList supprime une liste ; ses cartes sont déplacées vers moveTo,
+// obligatoire si la liste n'est pas vide
+func (a *App) DeleteList(slug, moveTo string) error {
+	var builtin bool
+	if err := a.db.QueryRow("SELECT builtin FROM lists WHERE slug = ?", slug).Scan(&builtin); err != nil {
+		return fmt.Errorf("liste inconnue: %s", slug)
+	}
+	if builtin {
+		return fmt.Errorf("la liste %s ne peut pas être supprimée", slug)
+	}
+
+	var count int
+	if err := a.db.QueryRow("SELECT COUNT(*) FROM cards WHERE type = ?", slug).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		if moveTo == "" || moveTo == slug {
+			return fmt.Errorf("la liste contient %d carte(s): choisissez une liste où les déplacer", count)
+		}
+		if err := a.requireList(moveTo); err != nil {
+			return err
+		}
+	}
+
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if count > 0 {
+		if _, err := tx.Exec("UPDATE cards SET type = ?, last_updated = CURRENT_TIMESTAMP WHERE type = ?", moveTo, slug); err != nil {
+			return fmt.Errorf("erreur déplacement des cartes: %v", err)
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM lists WHERE slug = ?", slug); err != nil {
+		return fmt.Errorf("erreur suppression de la liste: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("🗑️  Liste supprimée: %s (%d carte(s) déplacée(s) vers %s)", slug, count, moveTo)
+	return nil
+}
@@ -21,10 +21,13 @@ func main() {
 		Width:  1024,
 		Height: 768,
 		AssetServer: &assetserver.Options{
-			Assets: assets,
+			Assets:  assets,
+			Handler: imageHandler{}, // Images du cache local
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup:        app.OnStartup,
+		OnShutdown:       app.OnShutdown,
+		ErrorFormatter:   formatError,
 		Bind: []any{
 			app,
 		},
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+)
+
+// Marketplace est un site de vente dont les pages produit peuvent être suivies.
+// Chaque implémentation reconnaît ses URLs, fournit le script d'extraction exécuté dans la page
+// et convertit le résultat brut en informations produit et en offres.
+type Marketplace interface {
+	ID() string       // Identifiant stocké en base ("cardmarket", "tcgplayer")
+	Name() string     // Nom affiché
+	Currency() string // Devise des prix affichés (code ISO 4217)
+	Locale() string   // Langue des pages consultées, qui fixe le format des prix
+
+	// Recognizes indique si l'URL appartient à ce site
+	Recognizes(rawURL string) bool
+	// ParseURL valide une URL de page produit et calcule sa forme canonique
+	ParseURL(rawURL string) (*ProductURL, error)
+	// FilteredURL ajoute à l'URL produit les filtres du site correspondant aux critères
+	FilteredURL(productURL string, req AddCardRequest) (string, bool)
+
+	// IsNotFoundPage indique si le titre de la page ouverte est celui de la page d'erreur du site
+	IsNotFoundPage(title string) bool
+	// CookieBanner retourne les sélecteurs des boutons de la bannière cookies (CSS, ou XPath commençant par //)
+	CookieBanner() []string
+
+	// ExtractScript retourne le script JavaScript qui lit la page et retourne un pagePayload
+	ExtractScript() string
+	// ParseProduct lit l'en-tête du produit ; la raison retournée est non vide si la page est illisible
+	ParseProduct(payload *pagePayload) (*pageExtraction, string)
+	// ParseOffers convertit les lignes d'offres brutes, sans calcul du prix numérique
+	ParseOffers(payload *pagePayload, page *pageExtraction) []CardOffer
+}
+
+// ProductURL est une URL produit reconnue par un marketplace
+type ProductURL struct {
+	Marketplace string       `json:"marketplace"`
+	Canonical   string       `json:"canonical"`
+	Game        string       `json:"game"`
+	Criteria    CardCriteria `json:"criteria"`    // Critères exacts présents dans les filtres de l'URL
+	MinQuality  string       `json:"min_quality"` // État minimal filtré par l'URL, états supérieurs compris
+}
+
+// pagePayload est le résultat brut du script d'extraction d'une page produit
+type pagePayload struct {
+	Title           string           `json:"title"`
+	URL             string           `json:"url"`
+	Name            string           `json:"name"`
+	HasTitle        bool             `json:"has_title"`
+	EmptyMarker     bool             `json:"empty_marker"`
+	Rarity          string           `json:"rarity"`
+	SetName         string           `json:"set_name"`
+	ImageURL        string           `json:"image_url"`
+	Lang            string           `json:"lang"` // Attribut lang du document
+	SelectorVersion int              `json:"selector_version"`
+	Rows            []pagePayloadRow `json:"rows"`
+}
+
+// pagePayloadRow est une ligne d'offre brute
+type pagePayloadRow struct {
+	Mint     string   `json:"mint"`
+	Langue   string   `json:"langue"`
+	Edition  bool     `json:"edition"`
+	Specials []string `json:"specials"`
+	Price    string   `json:"price"`
+	Success  bool     `json:"success"`
+	Error    string   `json:"error"`
+}
+
+// priceHintFor retourne le contexte de lecture des prix d'une page du site
+func priceHintFor(market Marketplace, payload *pagePayload) priceHint {
+	locale := market.Locale()
+	if payload != nil && payload.Lang != "" {
+		locale = payload.Lang
+	}
+	return priceHint{Locale: locale, Currency: market.Currency()}
+}
+
+// newMarketplaces retourne les sites supportés ; le premier est le site par défaut
+func newMarketplaces(selectors *selectorStore) []Marketplace {
+	return []Marketplace{
+		&cardmarketMarketplace{selectors: selectors},
+		&tcgplayerMarketplace{},
+	}
+}
+
+// marketplaceFor retourne le site correspondant à une URL
+func (a *App) marketplaceFor(rawURL string) (Marketplace, error) {
+	for _, market := range a.markets {
+		if market.Recognizes(rawURL) {
+			return market, nil
+		}
+	}
+
+	var names []string
+	for _, market := range a.markets {
+		names = append(names, market.Name())
+	}
+	return nil, newScrapeError(ErrInvalidProductURL, "site non supporté",
+		fmt.Sprintf("%s (sites supportés: %s)", rawURL, strings.Join(names, ", ")))
+}
+
+// parseProductURL reconnaît le site d'une URL produit et la valide
+func (a *App) parseProductURL(rawURL string) (Marketplace, *ProductURL, error) {
+	market, err := a.marketplaceFor(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	productURL, err := market.ParseURL(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return market, productURL, nil
+}
+
+// applyURLCriteria complète les critères non renseignés à partir de ceux présents dans l'URL
+func applyURLCriteria(req *AddCardRequest, productURL *ProductURL) {
+	criteria := productURL.Criteria
+	if req.Language == "" {
+		req.Language = criteria.Language
+	}
+	if req.Quality == "" {
+		req.Quality = criteria.Quality
+	}
+	if req.Quality == "" && productURL.MinQuality != "" {
+		// Une carte suit un état exact : faute d'état demandé, l'état minimal de l'URL est suivi tel quel
+		log.Printf("État minimal %s de l'URL suivi comme état exact", productURL.MinQuality)
+		req.Quality = productURL.MinQuality
+	}
+	if criteria.Edition {
+		req.Edition = true
+	}
+}
+
+// isFilteredPage indique si l'URL d'une page produit porte des filtres d'offres du site
+func isFilteredPage(market Marketplace, pageURL string) bool {
+	parsed, err := market.ParseURL(pageURL)
+	return err == nil && (parsed.Criteria != CardCriteria{} || parsed.MinQuality != "")
+}
+
+// MarketplaceInfo décrit un site supporté pour le frontend
+type MarketplaceInfo struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+}
+
+// GetMarketplaces retourne les sites supportés
+func (a *App) GetMarketplaces() []MarketplaceInfo {
+	var infos []MarketplaceInfo
+	for _, market := range a.markets {
+		infos = append(infos, MarketplaceInfo{ID: market.ID(), Name: market.Name(), Currency: market.Currency()})
+	}
+	return infos
+}
+
+// parseLooseURL analyse une URL saisie par l'utilisateur, avec ou sans schéma
+func parseLooseURL(rawURL string) (*url.URL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, fmt.Errorf("URL manquante")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	return url.Parse(rawURL)
+}
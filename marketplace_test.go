@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Pages enregistrées de chaque site, dans testdata/<site>/ :
+//   - <nom>.html : la page d'origine
+//   - <nom>.payload.json : le résultat du script d'extraction sur cette page
+//   - <nom>.json : les informations et les offres attendues
+
+// marketplaceFixture est le résultat attendu pour une page enregistrée
+type marketplaceFixture struct {
+	Name     string      `json:"name"`
+	SetName  string      `json:"set_name"`
+	Rarity   string      `json:"rarity"`
+	ImageURL string      `json:"image_url"`
+	Offers   []CardOffer `json:"offers"`
+}
+
+// testMarketplaces retourne les sites supportés, avec le profil de sélecteurs embarqué
+func testMarketplaces(t *testing.T) []Marketplace {
+	return newMarketplaces(newSelectorStore(filepath.Join(t.TempDir(), "selectors.json")))
+}
+
+// testMarketplace retourne le site supporté d'identifiant id
+func testMarketplace(t *testing.T, id string) Marketplace {
+	for _, market := range testMarketplaces(t) {
+		if market.ID() == id {
+			return market
+		}
+	}
+	t.Fatalf("site %s non supporté", id)
+	return nil
+}
+
+// marketplaceFixtureNames retourne les pages enregistrées d'un site
+func marketplaceFixtureNames(t *testing.T, market Marketplace) []string {
+	paths, err := filepath.Glob(filepath.Join("testdata", market.ID(), "*.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("aucune page enregistrée pour %s", market.Name())
+	}
+
+	var names []string
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(path), ".html"))
+	}
+	return names
+}
+
+func readJSONFixture(t *testing.T, path string, v any) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("%s invalide: %v", path, err)
+	}
+}
+
+// testMarketplaceFixtures vérifie la lecture des pages enregistrées d'un site, sans navigateur
+func testMarketplaceFixtures(t *testing.T, market Marketplace) {
+	for _, name := range marketplaceFixtureNames(t, market) {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join("testdata", market.ID())
+			var payload pagePayload
+			readJSONFixture(t, filepath.Join(dir, name+".payload.json"), &payload)
+			var expected marketplaceFixture
+			readJSONFixture(t, filepath.Join(dir, name+".json"), &expected)
+
+			page, driftReason := market.ParseProduct(&payload)
+			if driftReason != "" {
+				t.Fatalf("page non reconnue: %s", driftReason)
+			}
+			if page.Name != expected.Name {
+				t.Errorf("nom: %q, attendu %q", page.Name, expected.Name)
+			}
+			if page.SetName != expected.SetName {
+				t.Errorf("set: %q, attendu %q", page.SetName, expected.SetName)
+			}
+			if page.Rarity != expected.Rarity {
+				t.Errorf("rareté: %q, attendu %q", page.Rarity, expected.Rarity)
+			}
+			if page.ImageURL != expected.ImageURL {
+				t.Errorf("image: %q, attendu %q", page.ImageURL, expected.ImageURL)
+			}
+
+			offers := market.ParseOffers(&payload, page)
+			if len(offers) != len(expected.Offers) {
+				t.Fatalf("%d offres, attendu %d", len(offers), len(expected.Offers))
+			}
+			for i, got := range offers {
+				want := expected.Offers[i]
+				if got.Mint != want.Mint || got.Language != want.Language || got.Edition != want.Edition || got.Price != want.Price {
+					t.Errorf("offre %d: %s/%s/%t/%q, attendu %s/%s/%t/%q", i+1,
+						got.Mint, got.Language, got.Edition, got.Price, want.Mint, want.Language, want.Edition, want.Price)
+				}
+
+				price, err := parsePrice(got.Price, priceHintFor(market, &payload))
+				if err != nil {
+					t.Errorf("offre %d: %v", i+1, err)
+					continue
+				}
+				if math.Abs(price.Amount-want.PriceNum) > 0.001 || price.Currency != want.Currency {
+					t.Errorf("offre %d: prix %.2f %s, attendu %.2f %s", i+1, price.Amount, price.Currency, want.PriceNum, want.Currency)
+				}
+			}
+		})
+	}
+}
+
+func TestCardmarketFixtures(t *testing.T) {
+	testMarketplaceFixtures(t, testMarketplace(t, "cardmarket"))
+}
+
+func TestIsNotFoundPage(t *testing.T) {
+	tests := []struct {
+		market   string
+		title    string
+		notFound bool
+	}{
+		{"cardmarket", "Page introuvable | Cardmarket", true},
+		{"cardmarket", "Dark Magician (V.1 - Ultra Rare) | Cardmarket", false},
+		{"tcgplayer", "404 - Page Not Found | TCGplayer", true},
+		{"tcgplayer", "Dark Magician - Legend of Blue Eyes White Dragon - YuGiOh | TCGplayer", false},
+	}
+
+	for _, tt := range tests {
+		if got := testMarketplace(t, tt.market).IsNotFoundPage(tt.title); got != tt.notFound {
+			t.Errorf("%s %q: %t, attendu %t", tt.market, tt.title, got, tt.notFound)
+		}
+	}
+}
+
+// TestExtractScripts exécute le script d'extraction de chaque site sur ses pages enregistrées
+// et compare le résultat au payload enregistré. Nécessite Chrome, ignoré sinon.
+func TestExtractScripts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("navigateur non lancé en mode -short")
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), scrapeChromeOptions()...)
+	defer allocCancel()
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	defer cancel()
+	if err := chromedp.Run(ctx); err != nil {
+		t.Skipf("navigateur indisponible: %v", err)
+	}
+
+	for _, market := range testMarketplaces(t) {
+		for _, name := range marketplaceFixtureNames(t, market) {
+			t.Run(market.ID()+"/"+name, func(t *testing.T) {
+				dir := filepath.Join("testdata", market.ID())
+				html, err := os.ReadFile(filepath.Join(dir, name+".html"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var expected pagePayload
+				readJSONFixture(t, filepath.Join(dir, name+".payload.json"), &expected)
+
+				// Charger la page depuis la mémoire, sans réseau
+				runCtx, runCancel := context.WithTimeout(ctx, 30*time.Second)
+				defer runCancel()
+				dataURL := "data:text/html;charset=utf-8;base64," + base64.StdEncoding.EncodeToString(html)
+				if err := chromedp.Run(runCtx, chromedp.Navigate(dataURL), chromedp.WaitReady("body", chromedp.ByQuery)); err != nil {
+					t.Fatalf("chargement impossible: %v", err)
+				}
+
+				payload, err := evaluatePage(runCtx, market)
+				if err != nil {
+					t.Fatalf("extraction impossible: %v", err)
+				}
+				payload.URL = expected.URL
+				if !reflect.DeepEqual(*payload, expected) {
+					t.Errorf("payload:\n%+v\nattendu:\n%+v", *payload, expected)
+				}
+			})
+		}
+	}
+}
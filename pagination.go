@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Attente maximale de nouvelles lignes après un clic sur Load More
+const loadMoreWait = 10 * time.Second
+
+// clickLoadMore clique sur le bouton Load More et attend l'apparition de nouvelles lignes.
+// Retourne false quand le bouton a disparu ou que plus aucune ligne n'arrive.
+func (a *App) clickLoadMore(ctx context.Context, previousRows int) (bool, error) {
+	selectors := a.selectors.Current()
+
+	var clicked bool
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(function() {
+			var btn = document.querySelector(%s);
+			if (btn === null || btn.offsetParent === null || btn.disabled) return false;
+			btn.scrollIntoView({block: 'center'});
+			btn.click();
+			return true;
+		})()
+	`, jsString(selectors.LoadMoreButton)), &clicked))
+	if err != nil {
+		return false, classifyScrapeError(fmt.Errorf("erreur lors du clic sur 'Load More': %w", err))
+	}
+	if !clicked {
+		return false, nil
+	}
+
+	// Attendre que de nouvelles lignes soient ajoutées à la liste
+	countScript := fmt.Sprintf("document.querySelectorAll(%s).length", jsString(selectors.OfferRow))
+	deadline := time.Now().Add(loadMoreWait)
+	for time.Now().Before(deadline) {
+		var count int
+		err := chromedp.Run(ctx,
+			chromedp.Sleep(500*time.Millisecond),
+			chromedp.Evaluate(countScript, &count),
+		)
+		if err != nil {
+			return false, classifyScrapeError(fmt.Errorf("erreur lors de l'attente des offres: %w", err))
+		}
+		if count > previousRows {
+			return true, nil
+		}
+	}
+
+	log.Printf("Aucune nouvelle ligne après 'Load More' (%d lignes)", previousRows)
+	return false, nil
+}
+
+// paginateOffers charge les offres suivantes jusqu'à trouver une offre correspondante,
+// épuiser la liste ou atteindre les limites configurées
+func (a *App) paginateOffers(ctx context.Context, page *pageExtraction, req AddCardRequest, metrics *ScrapeMetrics) (*pageExtraction, *CardOffer, error) {
+	settings := a.getScrapeSettings()
+	start := time.Now()
+
+	for {
+		if page.RowCount >= settings.MaxOfferRows {
+			log.Printf("⏹️  Limite de %d offres atteinte (%d lignes)", settings.MaxOfferRows, page.RowCount)
+			return page, nil, nil
+		}
+		if time.Since(start) >= settings.maxLoadDuration() {
+			log.Printf("⏹️  Limite de %ds de chargement atteinte (%d lignes)", settings.MaxLoadSeconds, page.RowCount)
+			return page, nil, nil
+		}
+
+		loaded, err := a.clickLoadMore(ctx, page.RowCount)
+		if err != nil {
+			return page, nil, err
+		}
+		if !loaded {
+			log.Printf("Toutes les offres sont chargées (%d lignes)", page.RowCount)
+			return page, nil, nil
+		}
+		metrics.LoadMoreClicks++
+
+		page, err = a.extractPage(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		metrics.record(page)
+
+		if result := a.findTheCard(page.Offers, req.Quality, req.Language, req.Edition); result != nil {
+			return page, result, nil
+		}
+	}
+}
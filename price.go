@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPrice   = errors.New("prix illisible")
+	ErrAmbiguousPrice = errors.New("prix ambigu")
+)
+
+// priceHint est le contexte de lecture d'un prix : langue de la page et devise du site
+type priceHint struct {
+	Locale   string // Langue de la page ("fr", "en-US", ...), vide si inconnue
+	Currency string // Devise retenue quand le prix n'en indique aucune
+}
+
+// ParsedPrice est un prix lu dans une page
+type ParsedPrice struct {
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency"`
+	Confidence float64 `json:"confidence"` // 1 si la lecture ne dépend d'aucune supposition
+	From       bool    `json:"from"`       // Prix minimum ("ab", "From", "à partir de")
+}
+
+// Contexte des pages CardMarket lues hors du parcours marketplace (recherche, modes de secours)
+var cardmarketPriceHint = priceHint{Locale: canonicalLocale, Currency: "EUR"}
+
+// Préfixes des prix minimum affichés dans les listes de produits, en minuscules
+var priceFromPrefixes = []string{"à partir de", "a partir de", "ab", "from", "starting at", "dès", "desde", "da", "vanaf", "od"}
+
+// Langues dont les pages utilisent le point comme séparateur décimal
+var dotDecimalLocales = []string{"en", "ja", "zh", "ko"}
+
+// Nombre avec séparateurs éventuels : chiffres, points, virgules, espaces et apostrophes
+var priceNumberPattern = regexp.MustCompile(`^\d[\d.,' ]*$`)
+
+// Groupe de milliers suivant un espace, éventuellement suivi des décimales
+var spaceGroupPattern = regexp.MustCompile(`^\d{3}(?:[.,]\d{1,2})?$`)
+
+// decimalSeparatorFor retourne le séparateur décimal attendu pour une langue, 0 si elle est inconnue
+func decimalSeparatorFor(locale string) rune {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == "" {
+		return 0
+	}
+	if strings.HasPrefix(locale, "de-ch") || strings.HasPrefix(locale, "fr-ch") || strings.HasPrefix(locale, "it-ch") {
+		return '.' // Suisse : 1'234.50
+	}
+	for _, prefix := range dotDecimalLocales {
+		if locale == prefix || strings.HasPrefix(locale, prefix+"-") {
+			return '.'
+		}
+	}
+	return ','
+}
+
+// parsePrice lit un prix affiché ("1.234,50 €", "$1,234.56", "ab 0,02 €", "CHF 1'250.00").
+// Les formats dont la lecture dépendrait d'une supposition non étayée par la langue de la page,
+// comme "1.234" sans indication de langue, sont rejetés plutôt que lus comme un prix nul ou faux.
+func parsePrice(text string, hint priceHint) (ParsedPrice, error) {
+	result := ParsedPrice{Confidence: 1}
+
+	// Espaces insécables utilisés comme séparateurs de milliers
+	normalized := strings.NewReplacer("\u00a0", " ", "\u202f", " ", "\u2009", " ", "\u2019", "'").Replace(text)
+	normalized = strings.TrimSpace(normalized)
+	if normalized == "" {
+		return result, fmt.Errorf("%w: texte vide", ErrInvalidPrice)
+	}
+
+	// Prix minimum d'une liste de produits
+	lower := strings.ToLower(normalized)
+	for _, prefix := range priceFromPrefixes {
+		if strings.HasPrefix(lower, prefix+" ") || strings.HasPrefix(lower, prefix+":") {
+			normalized = strings.TrimLeft(normalized[len(prefix):], ": ")
+			result.From = true
+			break
+		}
+	}
+
+	// Devise : indiquée dans le texte ou supposée d'après le site
+	result.Currency = detectCurrency(normalized, "")
+	if result.Currency == "" {
+		krona, found := detectKrona(normalized, hint)
+		if found && krona == "" {
+			return result, fmt.Errorf("%w: couronne \"kr\" sans langue scandinave ni devise du site (%q)", ErrAmbiguousPrice, text)
+		}
+		result.Currency = krona
+	}
+	if result.Currency == "" {
+		if hint.Currency == "" {
+			return result, fmt.Errorf("%w: devise inconnue dans %q", ErrInvalidPrice, text)
+		}
+		result.Currency = hint.Currency
+		result.Confidence -= 0.1
+	}
+	for _, marker := range currencyMarkers {
+		normalized = strings.ReplaceAll(normalized, marker.Marker, " ")
+	}
+	for _, marker := range kronaMarkers {
+		normalized = strings.ReplaceAll(normalized, marker, " ")
+	}
+	normalized = strings.Trim(strings.TrimSpace(normalized), "*")
+	normalized = strings.TrimSpace(strings.TrimSuffix(normalized, ",-"))
+
+	if strings.HasPrefix(normalized, "-") {
+		return result, fmt.Errorf("%w: prix négatif %q", ErrInvalidPrice, text)
+	}
+	if !priceNumberPattern.MatchString(normalized) {
+		return result, fmt.Errorf("%w: %q n'est pas un prix", ErrInvalidPrice, text)
+	}
+
+	amount, confidence, err := parsePriceNumber(normalized, decimalSeparatorFor(hint.Locale))
+	if err != nil {
+		return result, fmt.Errorf("%w (%q)", err, text)
+	}
+	result.Amount = amount
+	result.Confidence *= confidence
+	if result.From {
+		result.Confidence -= 0.1
+	}
+	return result, nil
+}
+
+// parsePriceNumber interprète les séparateurs d'un nombre ; decimal vaut 0 si la langue est inconnue
+func parsePriceNumber(number string, decimal rune) (float64, float64, error) {
+	// Les espaces ne servent qu'à grouper les milliers : "1 234,50", pas "3,50 5,00"
+	groups := strings.Fields(number)
+	if len(groups) > 1 {
+		if len(groups[0]) > 3 || strings.ContainsAny(groups[0], ".,'") {
+			return 0, 0, fmt.Errorf("%w: plusieurs nombres", ErrAmbiguousPrice)
+		}
+		for _, group := range groups[1:] {
+			if !spaceGroupPattern.MatchString(group) {
+				return 0, 0, fmt.Errorf("%w: plusieurs nombres", ErrAmbiguousPrice)
+			}
+		}
+	}
+	number = strings.Join(groups, "")
+	number = strings.ReplaceAll(number, "'", "")
+
+	lastDot := strings.LastIndex(number, ".")
+	lastComma := strings.LastIndex(number, ",")
+	confidence := 1.0
+
+	var integer, fraction string
+	var group string
+	switch {
+	case lastDot == -1 && lastComma == -1:
+		integer = number
+
+	case lastDot != -1 && lastComma != -1:
+		// Les deux séparateurs : le dernier est décimal
+		if lastDot > lastComma {
+			integer, fraction, group = number[:lastDot], number[lastDot+1:], ","
+		} else {
+			integer, fraction, group = number[:lastComma], number[lastComma+1:], "."
+		}
+		if decimal != 0 && !strings.ContainsRune(number[len(integer):], decimal) {
+			confidence = 0.9 // Format différent de celui de la langue de la page
+		}
+
+	default:
+		sep := "."
+		if lastComma != -1 {
+			sep = ","
+		}
+		parts := strings.Split(number, sep)
+		if len(parts) > 2 {
+			// Séparateur répété : milliers uniquement
+			integer, group = number, sep
+			break
+		}
+
+		if len(parts[1]) != 3 {
+			integer, fraction = parts[0], parts[1]
+			break
+		}
+
+		// "1.234" ou "1,234" : milliers ou décimales selon la langue
+		switch {
+		case decimal == 0:
+			return 0, 0, fmt.Errorf("%w: %q peut se lire de deux façons", ErrAmbiguousPrice, number)
+		case rune(sep[0]) == decimal:
+			return 0, 0, fmt.Errorf("%w: trois décimales dans %q", ErrAmbiguousPrice, number)
+		default:
+			integer, group = number, sep
+			confidence = 0.8
+		}
+	}
+
+	if group != "" {
+		chunks := strings.Split(integer, group)
+		if len(chunks[0]) == 0 || len(chunks[0]) > 3 {
+			return 0, 0, fmt.Errorf("%w: milliers mal groupés dans %q", ErrInvalidPrice, number)
+		}
+		for _, chunk := range chunks[1:] {
+			if len(chunk) != 3 {
+				return 0, 0, fmt.Errorf("%w: milliers mal groupés dans %q", ErrInvalidPrice, number)
+			}
+		}
+		integer = strings.Join(chunks, "")
+	}
+	if strings.ContainsAny(integer, ".,") || strings.ContainsAny(fraction, ".,") {
+		return 0, 0, fmt.Errorf("%w: séparateurs incohérents dans %q", ErrInvalidPrice, number)
+	}
+	if len(fraction) > 2 {
+		return 0, 0, fmt.Errorf("%w: trop de décimales dans %q", ErrAmbiguousPrice, number)
+	}
+
+	value := integer
+	if fraction != "" {
+		value += "." + fraction
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalidPrice, err)
+	}
+	return amount, confidence, nil
+}
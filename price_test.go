@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// priceCorpusEntry est un prix enregistré et sa lecture attendue
+type priceCorpusEntry struct {
+	Text         string  `json:"text"`
+	Locale       string  `json:"locale"`
+	HintCurrency string  `json:"hint_currency"` // Devise du site, si le prix n'en indique pas
+	Currency     string  `json:"currency"`
+	Amount       float64 `json:"amount"`
+	From         bool    `json:"from"`
+	Error        string  `json:"error"` // "ambiguous" ou "invalid" si le prix doit être rejeté
+}
+
+// TestParsePriceCorpus vérifie la lecture des prix enregistrés dans testdata/prices.json
+func TestParsePriceCorpus(t *testing.T) {
+	var corpus []priceCorpusEntry
+	readJSONFixture(t, filepath.Join("testdata", "prices.json"), &corpus)
+
+	for _, entry := range corpus {
+		t.Run(fmt.Sprintf("%q/%s", entry.Text, entry.Locale), func(t *testing.T) {
+			price, err := parsePrice(entry.Text, priceHint{Locale: entry.Locale, Currency: entry.HintCurrency})
+			switch entry.Error {
+			case "ambiguous":
+				if !errors.Is(err, ErrAmbiguousPrice) {
+					t.Errorf("prix ambigu attendu, obtenu %.2f %s (%v)", price.Amount, price.Currency, err)
+				}
+			case "invalid":
+				if !errors.Is(err, ErrInvalidPrice) {
+					t.Errorf("prix illisible attendu, obtenu %.2f %s (%v)", price.Amount, price.Currency, err)
+				}
+			case "":
+				if err != nil {
+					t.Fatalf("lecture impossible: %v", err)
+				}
+				if math.Abs(price.Amount-entry.Amount) > 0.001 || price.Currency != entry.Currency || price.From != entry.From {
+					t.Errorf("%.2f %s (minimum: %t), attendu %.2f %s (minimum: %t)",
+						price.Amount, price.Currency, price.From, entry.Amount, entry.Currency, entry.From)
+				}
+			default:
+				t.Fatalf("erreur attendue inconnue: %q", entry.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Taille de page par défaut et maximale de QueryCards
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+// Colonnes de tri acceptées par QueryCards ; "price" trie sur le prix converti dans la devise d'affichage
+var cardSortColumns = map[string]string{
+	"name":         "name COLLATE NOCASE",
+	"set_name":     "set_name COLLATE NOCASE",
+	"rarity":       "rarity COLLATE NOCASE",
+	"quality":      "quality",
+	"language":     "language",
+	"edition":      "edition",
+	"total_offers": "total_offers",
+	"game":         "game",
+	"type":         "type",
+	"added_at":     "added_at",
+	"last_updated": "last_updated",
+}
+
+// CardQuery décrit une recherche de cartes : filtres, tri et pagination.
+// Les champs vides ne filtrent pas.
+type CardQuery struct {
+	CardFilter
+	Type        string   `json:"type"`         // Liste ; vide = toutes les listes
+	Game        string   `json:"game"`         // Jeu ; vide = tous les jeux
+	Search      string   `json:"search"`       // Texte contenu dans le nom ou l'extension
+	Rarity      string   `json:"rarity"`       // Rareté exacte, insensible à la casse
+	Language    string   `json:"language"`     // Langue suivie
+	Quality     string   `json:"quality"`      // Qualité suivie (NM, LP, ...)
+	Edition     *bool    `json:"edition"`      // Première édition ou non ; nil = indifférent
+	MinPrice    *float64 `json:"min_price"`    // Dans la devise d'affichage
+	MaxPrice    *float64 `json:"max_price"`    // Dans la devise d'affichage
+	AddedFrom   string   `json:"added_from"`   // AAAA-MM-JJ inclus
+	AddedTo     string   `json:"added_to"`     // AAAA-MM-JJ inclus
+	UpdatedFrom string   `json:"updated_from"` // AAAA-MM-JJ inclus
+	UpdatedTo   string   `json:"updated_to"`   // AAAA-MM-JJ inclus
+	SortBy      string   `json:"sort_by"`      // Colonne de tri ; "added_at" par défaut
+	SortDesc    bool     `json:"sort_desc"`
+	Limit       int      `json:"limit"` // 50 par défaut, 1000 au plus
+	Offset      int      `json:"offset"`
+}
+
+// CardPage est une page de résultats de QueryCards
+type CardPage struct {
+	Cards  []Card `json:"cards"`
+	Total  int    `json:"total"` // Nombre de cartes correspondant aux filtres, toutes pages confondues
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// displayPriceExpr retourne une expression SQL du prix d'une carte converti dans la devise d'affichage ;
+// elle vaut NULL pour les devises sans taux de change
+func displayPriceExpr(rates map[string]float64, display string) (string, []any) {
+	return convertedAmountExpr("price_num", "currency", rates, display)
+}
+
+// convertedAmountExpr retourne une expression SQL convertissant la colonne amount, exprimée dans la devise
+// de la colonne currency, dans la devise display ; elle vaut NULL pour les devises sans taux de change
+func convertedAmountExpr(amount, currency string, rates map[string]float64, display string) (string, []any) {
+	currencies := make([]string, 0, len(rates))
+	for code := range rates {
+		currencies = append(currencies, code)
+	}
+	sort.Strings(currencies)
+
+	var expr strings.Builder
+	var args []any
+	expr.WriteString("(CASE COALESCE(NULLIF(" + currency + ", ''), 'EUR')")
+	for _, code := range currencies {
+		factor, ok := convertAmount(1, code, display, rates)
+		if !ok {
+			continue
+		}
+		expr.WriteString(" WHEN ? THEN " + amount + " * ?")
+		args = append(args, code, factor)
+	}
+	expr.WriteString(" END)")
+	return expr.String(), args
+}
+
+// dateBound vérifie une date AAAA-MM-JJ
+func dateBound(field, value string) error {
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return fmt.Errorf("date invalide pour %s: %q (format attendu AAAA-MM-JJ)", field, value)
+	}
+	return nil
+}
+
+// where retourne les conditions SQL de la recherche ; priceExpr est l'expression du prix converti
+func (q CardQuery) where(priceExpr string, priceArgs []any) (string, []any, error) {
+	clauses := []string{"1 = 1"}
+	var args []any
+
+	equal := func(column, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			clauses = append(clauses, column+" = ? COLLATE NOCASE")
+			args = append(args, value)
+		}
+	}
+	equal("type", q.Type)
+	equal("game", q.Game)
+	equal("rarity", q.Rarity)
+	equal("language", q.Language)
+	equal("quality", q.Quality)
+
+	if search := strings.TrimSpace(q.Search); search != "" {
+		pattern := "%" + escapeLike(search) + "%"
+		clauses = append(clauses, `(name LIKE ? ESCAPE '\' OR set_name LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+	if q.Edition != nil {
+		clauses = append(clauses, "COALESCE(edition, FALSE) = ?")
+		args = append(args, *q.Edition)
+	}
+	if q.MinPrice != nil {
+		clauses = append(clauses, priceExpr+" >= ?")
+		args = append(append(args, priceArgs...), *q.MinPrice)
+	}
+	if q.MaxPrice != nil {
+		clauses = append(clauses, priceExpr+" <= ?")
+		args = append(append(args, priceArgs...), *q.MaxPrice)
+	}
+
+	dates := []struct {
+		field, column, value, op, bound string
+	}{
+		{"added_from", "added_at", q.AddedFrom, ">=", "date(?)"},
+		{"added_to", "added_at", q.AddedTo, "<", "date(?, '+1 day')"},
+		{"updated_from", "last_updated", q.UpdatedFrom, ">=", "date(?)"},
+		{"updated_to", "last_updated", q.UpdatedTo, "<", "date(?, '+1 day')"},
+	}
+	for _, d := range dates {
+		if d.value == "" {
+			continue
+		}
+		if err := dateBound(d.field, d.value); err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, d.column+" "+d.op+" "+d.bound)
+		args = append(args, d.value)
+	}
+
+	filterWhere, filterArgs := q.CardFilter.where()
+	return strings.Join(clauses, " AND ") + filterWhere, append(args, filterArgs...), nil
+}
+
+// QueryCards recherche des cartes côté base : filtres, tri sur n'importe quelle colonne et pagination,
+// avec le nombre total de résultats
+func (a *App) QueryCards(query CardQuery) (*CardPage, error) {
+	if query.Limit <= 0 {
+		query.Limit = defaultPageSize
+	}
+	if query.Limit > maxPageSize {
+		query.Limit = maxPageSize
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	rates, err := a.exchangeRates()
+	if err != nil {
+		return nil, err
+	}
+	priceExpr, priceArgs := displayPriceExpr(rates, a.getDisplayCurrency())
+
+	where, args, err := query.where(priceExpr, priceArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tri : colonne demandée puis identifiant, pour une pagination stable
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = "added_at"
+	}
+	direction := "ASC"
+	if query.SortDesc {
+		direction = "DESC"
+	}
+	var orderBy string
+	var orderArgs []any
+	if sortBy == "price" {
+		// Les prix non convertibles sont toujours placés en fin de liste
+		orderBy = priceExpr + " IS NULL, " + priceExpr + " " + direction
+		orderArgs = append(append(orderArgs, priceArgs...), priceArgs...)
+	} else if column, ok := cardSortColumns[sortBy]; ok {
+		orderBy = column + " " + direction
+	} else {
+		return nil, fmt.Errorf("colonne de tri inconnue: %s", sortBy)
+	}
+	orderBy += ", id " + direction
+
+	page := &CardPage{Cards: []Card{}, Limit: query.Limit, Offset: query.Offset}
+	if err := a.db.QueryRow("SELECT COUNT(*) FROM cards WHERE "+where, args...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	queryArgs := append(append(append([]any{}, args...), orderArgs...), query.Limit, query.Offset)
+	rows, err := a.db.Query("SELECT "+cardColumns+" FROM cards WHERE "+where+" ORDER BY "+orderBy+" LIMIT ? OFFSET ?", queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		card, err := scanCard(rows)
+		if err != nil {
+			return nil, err
+		}
+		page.Cards = append(page.Cards, *card)
+	}
+	return page, rows.Err()
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"log"
+)
+
+// RefreshOverrides remplace ponctuellement les critères enregistrés d'une carte ; un champ vide garde la valeur enregistrée
+type RefreshOverrides struct {
+	Quality  string `json:"quality"`
+	Language string `json:"language"`
+	Edition  *bool  `json:"edition"`
+}
+
+// CardSnapshot regroupe les champs d'une carte mis à jour par le scraping
+type CardSnapshot struct {
+	Name        string  `json:"name"`
+	Set         string  `json:"set_name"`
+	Rarity      string  `json:"rarity"`
+	Price       string  `json:"price"`
+	PriceNum    float64 `json:"price_num"`
+	Currency    string  `json:"currency"`
+	TotalOffers int     `json:"total_offers"`
+}
+
+// CardRefresh est le résultat de la mise à jour d'une carte
+type CardRefresh struct {
+	Card       *Card        `json:"card"`
+	Before     CardSnapshot `json:"before"`
+	After      CardSnapshot `json:"after"`
+	Changed    []string     `json:"changed"`     // Champs modifiés (noms JSON de CardSnapshot)
+	PriceDelta float64      `json:"price_delta"` // Variation du prix, dans la devise de la carte
+}
+
+func snapshotCard(card *Card) CardSnapshot {
+	return CardSnapshot{
+		Name:        card.Name,
+		Set:         card.Set,
+		Rarity:      card.Rarity,
+		Price:       card.Price,
+		PriceNum:    card.PriceNum,
+		Currency:    card.Currency,
+		TotalOffers: card.TotalOffers,
+	}
+}
+
+// changedFields liste les champs qui diffèrent entre deux états d'une carte
+func changedFields(before, after CardSnapshot) []string {
+	changed := []string{}
+	if before.Name != after.Name {
+		changed = append(changed, "name")
+	}
+	if before.Set != after.Set {
+		changed = append(changed, "set_name")
+	}
+	if before.Rarity != after.Rarity {
+		changed = append(changed, "rarity")
+	}
+	if before.Price != after.Price || before.PriceNum != after.PriceNum {
+		changed = append(changed, "price")
+	}
+	if before.Currency != after.Currency {
+		changed = append(changed, "currency")
+	}
+	if before.TotalOffers != after.TotalOffers {
+		changed = append(changed, "total_offers")
+	}
+	return changed
+}
+
+// cardRequest retourne la requête de scraping correspondant aux critères enregistrés d'une carte
+func cardRequest(card *Card) AddCardRequest {
+	return AddCardRequest{
+		URL:      card.CardURL,
+		Type:     card.Type,
+		Quality:  card.Quality,
+		Language: card.Language,
+		Edition:  card.Edition,
+	}
+}
+
+// saveScrapedInfo enregistre tous les champs issus du scraping d'une carte et les critères utilisés.
+// Le prix remplacé est conservé dans previous_price_num pour suivre les variations.
+func (a *App) saveScrapedInfo(cardID int, req AddCardRequest, cardInfo *ScrapedCardInfo) error {
+	_, err := a.db.Exec(`
+		UPDATE cards
+		SET previous_price_num = price_num, previous_currency = currency,
+		    name = ?, set_name = ?, rarity = ?, price = ?, price_num = ?, currency = ?,
+		    image_url = COALESCE(NULLIF(?, ''), image_url), total_offers = ?,
+		    quality = ?, language = ?, edition = ?, last_updated = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, cardInfo.Name, cardInfo.Set, cardInfo.Rarity, cardInfo.Price, cardInfo.PriceNum, currencyOrDefault(cardInfo.Currency),
+		cardInfo.ImageURL, len(cardInfo.Offers), req.Quality, req.Language, req.Edition, cardID)
+	if err != nil {
+		return fmt.Errorf("erreur sauvegarde %v", err)
+	}
+	return nil
+}
+
+// RefreshCard rescrape une carte avec ses critères enregistrés, éventuellement remplacés par overrides,
+// met à jour tous les champs et retourne l'état avant et après.
+// Les critères remplacés sont validés comme dans UpdateCardCriteria, enregistrés avec le nouveau prix
+// qu'ils décrivent et ajoutés à l'historique des critères.
+func (a *App) RefreshCard(cardID int, overrides *RefreshOverrides) (*CardRefresh, error) {
+	card, err := a.getCardByID(cardID)
+	if err != nil {
+		return nil, fmt.Errorf("carte %d introuvable: %v", cardID, err)
+	}
+
+	req := cardRequest(card)
+	if overrides != nil {
+		if overrides.Quality != "" {
+			req.Quality = overrides.Quality
+		}
+		if overrides.Language != "" {
+			req.Language = overrides.Language
+		}
+		if overrides.Edition != nil {
+			req.Edition = *overrides.Edition
+		}
+		req.Quality, req.Language, err = validateCriteria(card.Game, req.Quality, req.Language, req.Edition)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	refresh, err := a.refreshCard(card, req)
+	if err != nil {
+		return nil, err
+	}
+	a.recordCriteriaChange(card, req, true)
+	return refresh, nil
+}
+
+// refreshCard rescrape une carte avec les critères de req, enregistrés avec le résultat
+func (a *App) refreshCard(card *Card, req AddCardRequest) (*CardRefresh, error) {
+	log.Printf("🔄 Mise à jour de la carte %d (qualité: %s, langue: %s, édition: %t)", card.ID, req.Quality, req.Language, req.Edition)
+	cardInfo, err := a.scrapeCardInfo(card.CardURL, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.saveScrapedInfo(card.ID, req, cardInfo); err != nil {
+		return nil, err
+	}
+
+	updated, err := a.getCardByID(card.ID)
+	if err != nil {
+		return nil, err
+	}
+	a.cacheCardImage(updated, false)
+
+	refresh := &CardRefresh{
+		Card:   updated,
+		Before: snapshotCard(card),
+		After:  snapshotCard(updated),
+	}
+	refresh.Changed = changedFields(refresh.Before, refresh.After)
+	if refresh.Before.Currency == refresh.After.Currency {
+		refresh.PriceDelta = refresh.After.PriceNum - refresh.Before.PriceNum
+	}
+
+	log.Printf("✅ Carte %d mise à jour: %s -> %s, champs modifiés: %v", card.ID, card.Price, updated.Price, refresh.Changed)
+	return refresh, nil
+}
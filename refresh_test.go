@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+// TestRefreshCardRejectsInvalidOverrides vérifie que des critères remplacés invalides sont refusés avant tout scraping
+func TestRefreshCardRejectsInvalidOverrides(t *testing.T) {
+	app := newTestApp(t)
+	cardID := insertTestCard(t, app, Card{
+		Name: "Dark Magician", Price: "45,00 €", PriceNum: 45, Currency: "EUR", Type: "collection",
+		CardURL:  "https://www.cardmarket.com/fr/YuGiOh/Products/Singles/Legend-of-Blue-Eyes-White-Dragon/Dark-Magician-V1-Ultra-Rare",
+		Quality:  "NM",
+		Language: "Français",
+		Game:     "YuGiOh",
+	})
+
+	for _, overrides := range []RefreshOverrides{{Quality: "ZZ"}, {Language: "Klingon"}} {
+		if _, err := app.RefreshCard(cardID, &overrides); err == nil {
+			t.Errorf("critères %+v acceptés", overrides)
+		}
+	}
+
+	changes, err := app.GetCriteriaChanges(cardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	card, err := app.getCardByID(cardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 || card.Quality != "NM" || card.Language != "Français" {
+		t.Errorf("critères modifiés: %s/%s, %d changement(s)", card.Quality, card.Language, len(changes))
+	}
+}
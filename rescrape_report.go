@@ -0,0 +1,283 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+)
+
+// Nombre de plus fortes hausses et baisses retenues dans le résumé d'un rescrap
+const rescrapeTopMovers = 5
+
+// PriceMovement est la variation du prix d'une carte lors d'un rescrap
+type PriceMovement struct {
+	CardID        int     `json:"card_id"`
+	Name          string  `json:"name"`
+	Currency      string  `json:"currency"`  // Devise du nouveau prix
+	OldPrice      float64 `json:"old_price"` // Converti dans la devise du nouveau prix si elle a changé
+	NewPrice      float64 `json:"new_price"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"change_percent"` // 0 si l'ancien prix était nul
+	ValueChange   float64 `json:"value_change"`   // Variation dans la devise d'affichage, pour classer les cartes entre elles
+	OldOffers     int     `json:"old_offers"`
+	NewOffers     int     `json:"new_offers"`
+	OffersChange  int     `json:"offers_change"`
+	Status        string  `json:"status"` // "updated" ou "failed"
+	Error         string  `json:"error,omitempty"`
+}
+
+// RescrapeRun est le rapport d'un rescrap de toutes les cartes
+type RescrapeRun struct {
+	ID          int             `json:"id"`
+	StartedAt   string          `json:"started_at"`
+	FinishedAt  string          `json:"finished_at"`
+	Currency    string          `json:"currency"` // Devise d'affichage des valeurs totales
+	TotalCards  int             `json:"total_cards"`
+	Updated     int             `json:"updated"`
+	Errors      int             `json:"errors"`
+	Paused      bool            `json:"paused"`
+	ValueBefore float64         `json:"value_before"`
+	ValueAfter  float64         `json:"value_after"`
+	ValueChange float64         `json:"value_change"`
+	Gainers     []PriceMovement `json:"gainers,omitempty"`   // Plus fortes hausses
+	Losers      []PriceMovement `json:"losers,omitempty"`    // Plus fortes baisses
+	Movements   []PriceMovement `json:"movements,omitempty"` // Toutes les cartes traitées
+}
+
+// newPriceMovement compare une carte avant et après son rescrap
+func newPriceMovement(before, after *Card, rates map[string]float64, display string) PriceMovement {
+	movement := PriceMovement{
+		CardID:    after.ID,
+		Name:      after.Name,
+		Currency:  currencyOrDefault(after.Currency),
+		OldPrice:  before.PriceNum,
+		NewPrice:  after.PriceNum,
+		OldOffers: before.TotalOffers,
+		NewOffers: after.TotalOffers,
+		Status:    "updated",
+	}
+	if currencyOrDefault(before.Currency) != movement.Currency {
+		if converted, ok := convertAmount(before.PriceNum, before.Currency, movement.Currency, rates); ok {
+			movement.OldPrice = converted
+		} else {
+			movement.OldPrice = movement.NewPrice // Variation inconnue faute de taux
+		}
+	}
+
+	movement.Change = movement.NewPrice - movement.OldPrice
+	if movement.OldPrice > 0 {
+		movement.ChangePercent = movement.Change * 100 / movement.OldPrice
+	}
+	movement.ValueChange, _ = convertAmount(movement.Change, movement.Currency, display, rates)
+	movement.OffersChange = movement.NewOffers - movement.OldOffers
+	return movement
+}
+
+// failedPriceMovement décrit une carte dont le rescrap a échoué : son prix n'a pas changé
+func failedPriceMovement(card *Card, err error) PriceMovement {
+	return PriceMovement{
+		CardID:    card.ID,
+		Name:      card.Name,
+		Currency:  currencyOrDefault(card.Currency),
+		OldPrice:  card.PriceNum,
+		NewPrice:  card.PriceNum,
+		OldOffers: card.TotalOffers,
+		NewOffers: card.TotalOffers,
+		Status:    "failed",
+		Error:     err.Error(),
+	}
+}
+
+// summarizeMovements calcule les valeurs totales avant/après et les plus fortes hausses et baisses
+func (run *RescrapeRun) summarizeMovements(rates map[string]float64) {
+	run.ValueBefore, run.ValueAfter = 0, 0
+	for _, movement := range run.Movements {
+		if before, ok := convertAmount(movement.OldPrice, movement.Currency, run.Currency, rates); ok {
+			run.ValueBefore += before
+		}
+		if after, ok := convertAmount(movement.NewPrice, movement.Currency, run.Currency, rates); ok {
+			run.ValueAfter += after
+		}
+	}
+	run.ValueChange = run.ValueAfter - run.ValueBefore
+
+	sorted := append([]PriceMovement{}, run.Movements...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ValueChange > sorted[j].ValueChange })
+	run.Gainers, run.Losers = []PriceMovement{}, []PriceMovement{}
+	for _, movement := range sorted {
+		if movement.ValueChange <= 0 || len(run.Gainers) == rescrapeTopMovers {
+			break
+		}
+		run.Gainers = append(run.Gainers, movement)
+	}
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if sorted[i].ValueChange >= 0 || len(run.Losers) == rescrapeTopMovers {
+			break
+		}
+		run.Losers = append(run.Losers, sorted[i])
+	}
+}
+
+// startRescrapeRun enregistre le début d'un rescrap et retourne son rapport vide
+func (a *App) startRescrapeRun(totalCards int) (*RescrapeRun, error) {
+	run := &RescrapeRun{Currency: a.getDisplayCurrency(), TotalCards: totalCards, Movements: []PriceMovement{}}
+	res, err := a.db.Exec("INSERT INTO rescrape_runs (currency, total_cards) VALUES (?, ?)", run.Currency, totalCards)
+	if err != nil {
+		return nil, fmt.Errorf("erreur création du rapport de rescrap: %v", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	run.ID = int(id)
+	a.db.QueryRow("SELECT started_at FROM rescrape_runs WHERE id = ?", run.ID).Scan(&run.StartedAt)
+	return run, nil
+}
+
+// saveRescrapeMovement enregistre la variation d'une carte dès qu'elle est traitée, avec les totaux du rapport :
+// un rescrap interrompu garde les cartes déjà traitées. Une carte traitée à nouveau (reprise des échecs)
+// remplace sa variation précédente.
+func (a *App) saveRescrapeMovement(run *RescrapeRun, m PriceMovement, rates map[string]float64) error {
+	replaced := false
+	for i := range run.Movements {
+		if run.Movements[i].CardID == m.CardID {
+			run.Movements[i], replaced = m, true
+		}
+	}
+	if !replaced {
+		run.Movements = append(run.Movements, m)
+	}
+	run.Updated, run.Errors = 0, 0
+	for _, movement := range run.Movements {
+		if movement.Status == "failed" {
+			run.Errors++
+		} else {
+			run.Updated++
+		}
+	}
+	run.summarizeMovements(rates)
+
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM rescrape_run_items WHERE run_id = ? AND card_id = ?", run.ID, m.CardID); err != nil {
+		return fmt.Errorf("erreur sauvegarde du rapport de rescrap: %v", err)
+	}
+	_, err = tx.Exec(`
+		INSERT INTO rescrape_run_items (run_id, card_id, name, currency, old_price, new_price, change, change_percent,
+			value_change, old_offers, new_offers, status, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, run.ID, m.CardID, m.Name, m.Currency, m.OldPrice, m.NewPrice, m.Change, m.ChangePercent,
+		m.ValueChange, m.OldOffers, m.NewOffers, m.Status, m.Error)
+	if err != nil {
+		return fmt.Errorf("erreur sauvegarde du rapport de rescrap: %v", err)
+	}
+	_, err = tx.Exec("UPDATE rescrape_runs SET updated = ?, errors = ?, value_before = ?, value_after = ? WHERE id = ?",
+		run.Updated, run.Errors, run.ValueBefore, run.ValueAfter, run.ID)
+	if err != nil {
+		return fmt.Errorf("erreur sauvegarde du rapport de rescrap: %v", err)
+	}
+	return tx.Commit()
+}
+
+// finishRescrapeRun marque la fin d'une exécution du rescrap ; un rescrap en pause n'est pas terminé
+// et sera complété par la reprise du job
+func (a *App) finishRescrapeRun(run *RescrapeRun) error {
+	_, err := a.db.Exec(`
+		UPDATE rescrape_runs
+		SET finished_at = CASE WHEN ? THEN NULL ELSE CURRENT_TIMESTAMP END, paused = ?
+		WHERE id = ?
+	`, run.Paused, run.Paused, run.ID)
+	if err != nil {
+		return fmt.Errorf("erreur sauvegarde du rapport de rescrap: %v", err)
+	}
+
+	var finishedAt sql.NullString
+	a.db.QueryRow("SELECT finished_at FROM rescrape_runs WHERE id = ?", run.ID).Scan(&finishedAt)
+	run.FinishedAt = finishedAt.String
+	log.Printf("📈 Rapport de rescrap %d: valeur %.2f -> %.2f %s", run.ID, run.ValueBefore, run.ValueAfter, run.Currency)
+	return nil
+}
+
+const rescrapeRunColumns = `id, started_at, finished_at, currency, total_cards, updated, errors, paused, value_before, value_after`
+
+func scanRescrapeRun(row rowScanner) (*RescrapeRun, error) {
+	var run RescrapeRun
+	var finishedAt sql.NullString
+	err := row.Scan(&run.ID, &run.StartedAt, &finishedAt, &run.Currency, &run.TotalCards,
+		&run.Updated, &run.Errors, &run.Paused, &run.ValueBefore, &run.ValueAfter)
+	if err != nil {
+		return nil, err
+	}
+	run.FinishedAt = finishedAt.String // Vide tant que le rescrap n'est pas terminé
+	run.ValueChange = run.ValueAfter - run.ValueBefore
+	return &run, nil
+}
+
+// GetRescrapeRuns retourne le résumé des rescraps passés, du plus récent au plus ancien
+func (a *App) GetRescrapeRuns() ([]RescrapeRun, error) {
+	rows, err := a.db.Query("SELECT " + rescrapeRunColumns + " FROM rescrape_runs ORDER BY id DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []RescrapeRun{}
+	for rows.Next() {
+		run, err := scanRescrapeRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, rows.Err()
+}
+
+// GetRescrapeRun retourne le rapport complet d'un rescrap : variation de chaque carte, plus fortes hausses et baisses
+func (a *App) GetRescrapeRun(id int) (*RescrapeRun, error) {
+	run, err := scanRescrapeRun(a.db.QueryRow("SELECT "+rescrapeRunColumns+" FROM rescrape_runs WHERE id = ?", id))
+	if err != nil {
+		return nil, fmt.Errorf("rapport de rescrap %d introuvable: %v", id, err)
+	}
+
+	rows, err := a.db.Query(`
+		SELECT card_id, name, currency, old_price, new_price, change, change_percent, value_change,
+		       old_offers, new_offers, status, COALESCE(error, '')
+		FROM rescrape_run_items
+		WHERE run_id = ?
+		ORDER BY id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	run.Movements = []PriceMovement{}
+	for rows.Next() {
+		var m PriceMovement
+		err := rows.Scan(&m.CardID, &m.Name, &m.Currency, &m.OldPrice, &m.NewPrice, &m.Change, &m.ChangePercent,
+			&m.ValueChange, &m.OldOffers, &m.NewOffers, &m.Status, &m.Error)
+		if err != nil {
+			return nil, err
+		}
+		m.OffersChange = m.NewOffers - m.OldOffers
+		run.Movements = append(run.Movements, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Les totaux enregistrés restent ceux du jour du rescrap ; seul le classement est recalculé
+	valueBefore, valueAfter := run.ValueBefore, run.ValueAfter
+	rates, err := a.exchangeRates()
+	if err != nil {
+		return nil, err
+	}
+	run.summarizeMovements(rates)
+	run.ValueBefore, run.ValueAfter, run.ValueChange = valueBefore, valueAfter, valueAfter-valueBefore
+	return run, nil
+}
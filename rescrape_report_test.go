@@ -0,0 +1,129 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+// TestRescrapeReportOffers vérifie que le rapport de rescrap suit le nombre d'offres lues sur la page
+func TestRescrapeReportOffers(t *testing.T) {
+	app := newTestApp(t)
+	cardID := insertTestCard(t, app, Card{
+		Name: "Dark Magician", Price: "45,00 €", PriceNum: 45, Currency: "EUR", Type: "collection",
+		CardURL:     "https://www.cardmarket.com/fr/YuGiOh/Products/Singles/Legend-of-Blue-Eyes-White-Dragon/Dark-Magician-V1-Ultra-Rare",
+		Quality:     "NM",
+		Language:    "Français",
+		TotalOffers: 3,
+	})
+	before, err := app.getCardByID(cardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Page relue avec 12 offres, dont l'offre retenue à 50 €
+	info := &ScrapedCardInfo{Name: "Dark Magician", Price: "50,00 €", PriceNum: 50, Currency: "EUR", Offers: make([]CardOffer, 12)}
+	if err := app.saveScrapedInfo(cardID, cardRequest(before), info); err != nil {
+		t.Fatal(err)
+	}
+	after, err := app.getCardByID(cardID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rates, err := app.exchangeRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err := app.startRescrapeRun(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.saveRescrapeMovement(run, newPriceMovement(before, after, rates, run.Currency), rates); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.finishRescrapeRun(run); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := app.GetRescrapeRun(run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Movements) != 1 {
+		t.Fatalf("%d variations, attendu 1", len(saved.Movements))
+	}
+	m := saved.Movements[0]
+	if m.OldOffers != 3 || m.NewOffers != 12 || m.OffersChange != 9 {
+		t.Errorf("offres %d -> %d (%+d), attendu 3 -> 12 (+9)", m.OldOffers, m.NewOffers, m.OffersChange)
+	}
+	if m.Change != 5 || saved.ValueChange != 5 || len(saved.Gainers) != 1 {
+		t.Errorf("variation %.2f, valeur %+.2f, %d hausse(s), attendu 5, +5 et 1", m.Change, saved.ValueChange, len(saved.Gainers))
+	}
+}
+
+// TestRescrapeReportResumedJob vérifie que les cartes traitées avant une interruption restent au rapport
+// et que la reprise du job complète ce même rapport
+func TestRescrapeReportResumedJob(t *testing.T) {
+	app := newTestApp(t)
+	card := Card{
+		Name: "Dark Magician", Price: "45,00 €", PriceNum: 45, Currency: "EUR", Type: "collection",
+		CardURL: "https://www.cardmarket.com/fr/YuGiOh/Products/Singles/Legend-of-Blue-Eyes-White-Dragon/Dark-Magician-V1-Ultra-Rare",
+	}
+	card.ID = insertTestCard(t, app, card)
+
+	run, err := app.startRescrapeRun(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := app.createJob("rescrape", "Mise à jour de toutes les cartes", rescrapeJobParams{RunID: run.ID}, []JobItem{
+		{Key: strconv.Itoa(card.ID), Label: "Dark Magician"},
+		{Key: "9999", Label: "Carte supprimée"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Première carte traitée, puis application fermée
+	rates, err := app.exchangeRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := card
+	after.PriceNum = 50
+	if err := app.saveRescrapeMovement(run, newPriceMovement(&card, &after, rates, run.Currency), rates); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.db.Exec("UPDATE job_items SET status = 'done' WHERE job_id = ? AND position = 0", job.ID); err != nil {
+		t.Fatal(err)
+	}
+	app.markInterruptedJobs()
+
+	saved, err := app.GetRescrapeRun(run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Movements) != 1 || saved.Updated != 1 || saved.ValueChange != 5 || saved.FinishedAt != "" {
+		t.Fatalf("rapport interrompu: %d variation(s), %d à jour, %+.2f, fin %q, attendu 1, 1, +5 et pas de fin",
+			len(saved.Movements), saved.Updated, saved.ValueChange, saved.FinishedAt)
+	}
+
+	// La reprise traite la carte restante dans le même rapport
+	if _, err := app.ResumeJob(job.ID); err != nil {
+		t.Fatal(err)
+	}
+	runs, err := app.GetRescrapeRuns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("%d rapport(s), attendu 1", len(runs))
+	}
+	saved, err = app.GetRescrapeRun(run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Movements) != 1 || saved.TotalCards != 2 || saved.FinishedAt == "" {
+		t.Errorf("rapport repris: %d variation(s) sur %d cartes, fin %q, attendu 1 sur 2 et une fin",
+			len(saved.Movements), saved.TotalCards, saved.FinishedAt)
+	}
+}
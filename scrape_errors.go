@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Erreurs typées renvoyées par la couche de scraping
+var (
+	ErrBrowserUnavailable  = errors.New("navigateur indisponible")
+	ErrCloudflareChallenge = errors.New("challenge Cloudflare détecté")
+	ErrRateLimited         = errors.New("trop de requêtes envoyées à CardMarket")
+	ErrProductNotFound     = errors.New("produit introuvable")
+	ErrNoMatchingOffer     = errors.New("aucune offre correspondant aux critères")
+	ErrScrapeTimeout       = errors.New("délai dépassé lors du scraping")
+	ErrLayoutChanged       = errors.New("structure de la page modifiée")
+	ErrInvalidProductURL   = errors.New("URL de produit CardMarket invalide")
+)
+
+// Codes transmis au frontend pour chaque erreur typée
+var scrapeErrorCodes = map[error]string{
+	ErrBrowserUnavailable:  "browser_unavailable",
+	ErrCloudflareChallenge: "cloudflare_challenge",
+	ErrRateLimited:         "rate_limited",
+	ErrProductNotFound:     "product_not_found",
+	ErrNoMatchingOffer:     "no_matching_offer",
+	ErrScrapeTimeout:       "timeout",
+	ErrLayoutChanged:       "layout_changed",
+	ErrInvalidProductURL:   "invalid_url",
+}
+
+// ScrapeError est l'erreur structurée exposée au frontend sous la forme {code, message, details}
+type ScrapeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+	Err     error  `json:"-"` // Erreur sentinelle d'origine
+}
+
+func (e *ScrapeError) Error() string {
+	if e.Details != "" {
+		return fmt.Sprintf("%s: %s", e.Message, e.Details)
+	}
+	return e.Message
+}
+
+func (e *ScrapeError) Unwrap() error {
+	return e.Err
+}
+
+// newScrapeError crée une erreur typée à partir d'une sentinelle
+func newScrapeError(sentinel error, message string, details string) *ScrapeError {
+	return &ScrapeError{
+		Code:    scrapeErrorCodes[sentinel],
+		Message: message,
+		Details: details,
+		Err:     sentinel,
+	}
+}
+
+// classifyScrapeError convertit une erreur brute (chromedp, contexte) en erreur typée
+func classifyScrapeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var scrapeErr *ScrapeError
+	if errors.As(err, &scrapeErr) {
+		return err
+	}
+
+	for sentinel := range scrapeErrorCodes {
+		if errors.Is(err, sentinel) {
+			return newScrapeError(sentinel, sentinel.Error(), err.Error())
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || strings.Contains(err.Error(), "context deadline exceeded") {
+		return newScrapeError(ErrScrapeTimeout, ErrScrapeTimeout.Error(), err.Error())
+	}
+
+	return err
+}
+
+// formatError est utilisé par Wails pour transmettre les erreurs au frontend
+func formatError(err error) any {
+	var scrapeErr *ScrapeError
+	if errors.As(err, &scrapeErr) {
+		return scrapeErr
+	}
+
+	return map[string]string{
+		"code":    "unknown",
+		"message": err.Error(),
+		"details": "",
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	// Jeu utilisé quand aucun jeu n'est précisé
+	defaultGame = "YuGiOh"
+
+	// Nombre maximal de candidats retournés par une recherche
+	maxSearchResults = 50
+)
+
+// SearchCandidate est un produit trouvé par une recherche CardMarket
+type SearchCandidate struct {
+	Name         string  `json:"name"`
+	Set          string  `json:"set_name"`
+	Rarity       string  `json:"rarity"`
+	ImageURL     string  `json:"image_url"`
+	ProductURL   string  `json:"product_url"`
+	PriceFrom    string  `json:"price_from"`
+	PriceFromNum float64 `json:"price_from_num"`
+	Currency     string  `json:"currency"`
+}
+
+// productListPage contient les produits d'une page de liste (recherche ou extension)
+type productListPage struct {
+	Products []SearchCandidate
+	NextURL  string
+}
+
+// SearchCards recherche des cartes par nom sur CardMarket et retourne les produits correspondants
+func (a *App) SearchCards(query, game string) ([]SearchCandidate, error) {
+	query = strings.TrimSpace(query)
+	if len([]rune(query)) < 2 {
+		return nil, fmt.Errorf("la recherche doit contenir au moins 2 caractères")
+	}
+	if game == "" {
+		game = defaultGame
+	}
+
+	searchURL := fmt.Sprintf("https://www.cardmarket.com/%s/%s/Products/Search?searchString=%s",
+		canonicalLocale, url.PathEscape(game), url.QueryEscape(query))
+	log.Printf("🔍 Recherche de cartes: %q (%s)", query, game)
+
+	// Respecter le backoff après un blocage anti-bot
+	if delay := a.backoff.Delay(); delay > 0 {
+		log.Printf("⏳ Backoff anti-bot: attente de %v avant recherche", delay.Round(time.Second))
+		time.Sleep(delay)
+	}
+
+	tab, err := a.browsers.Acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(tab.ctx, scrapeTimeout)
+	defer cancel()
+
+	candidates, err := a.searchCardsInTab(ctx, searchURL)
+	a.browsers.Release(tab, err)
+	if err != nil {
+		if isChallengeError(err) {
+			a.backoff.Failure()
+		}
+		log.Printf("❌ Erreur recherche: %v", err)
+		return nil, classifyScrapeError(err)
+	}
+	a.backoff.Success()
+
+	log.Printf("✅ %d carte(s) trouvée(s) pour %q", len(candidates), query)
+	return candidates, nil
+}
+
+// searchCardsInTab charge la page de résultats et en extrait les candidats
+func (a *App) searchCardsInTab(ctx context.Context, searchURL string) ([]SearchCandidate, error) {
+	if err := a.getPage(false, ctx, searchURL); err != nil {
+		return nil, err
+	}
+
+	// CardMarket redirige directement vers la fiche produit quand un seul produit correspond
+	var location string
+	if err := chromedp.Run(ctx, chromedp.Location(&location)); err != nil {
+		return nil, classifyScrapeError(fmt.Errorf("erreur lors de la lecture de l'URL: %w", err))
+	}
+	if productURL, err := parseCardmarketURL(location); err == nil {
+		log.Println("Résultat unique, redirection vers la fiche produit")
+		return a.productPageCandidate(ctx, productURL.Canonical)
+	}
+
+	page, err := a.extractProductList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := page.Products
+	if len(candidates) > maxSearchResults {
+		candidates = candidates[:maxSearchResults]
+	}
+	return candidates, nil
+}
+
+// productPageCandidate construit un candidat à partir d'une fiche produit déjà chargée
+func (a *App) productPageCandidate(ctx context.Context, productURL string) ([]SearchCandidate, error) {
+	page, err := a.getInfos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := SearchCandidate{
+		Name:       page.Name,
+		Set:        page.SetName,
+		Rarity:     page.Rarity,
+		ImageURL:   page.ImageURL,
+		ProductURL: productURL,
+	}
+
+	// Prix "à partir de" : l'offre la moins chère parmi celles chargées
+	for _, offer := range page.Offers {
+		if offer.PriceNum > 0 && (candidate.PriceFromNum == 0 || offer.PriceNum < candidate.PriceFromNum) {
+			candidate.PriceFrom = offer.Price
+			candidate.PriceFromNum = offer.PriceNum
+			candidate.Currency = offer.Currency
+		}
+	}
+
+	return []SearchCandidate{candidate}, nil
+}
+
+// extractProductList lit les produits d'une page de liste CardMarket et le lien vers la page suivante
+func (a *App) extractProductList(ctx context.Context) (*productListPage, error) {
+	selectors := a.selectors.Current()
+
+	// Laisser le temps à la liste de s'afficher
+	ctxTimeout, cancelTimeout := context.WithTimeout(ctx, 20*time.Second)
+	defer cancelTimeout()
+	if err := chromedp.Run(ctxTimeout, chromedp.WaitVisible("body", chromedp.ByQuery), chromedp.Sleep(2*time.Second)); err != nil {
+		return nil, classifyScrapeError(fmt.Errorf("erreur lors de l'attente de la liste: %w", err))
+	}
+
+	var payload struct {
+		URL         string `json:"url"`
+		NextURL     string `json:"next_url"`
+		EmptyMarker bool   `json:"empty_marker"`
+		Rows        []struct {
+			Name   string `json:"name"`
+			Href   string `json:"href"`
+			Set    string `json:"set"`
+			Rarity string `json:"rarity"`
+			Image  string `json:"image"`
+			Price  string `json:"price"`
+		} `json:"rows"`
+	}
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(function() {
+			var sel = %s;
+			var result = {url: window.location.href, next_url: '', rows: []};
+
+			var rows = document.querySelectorAll(sel.product_list_row);
+			for (var i = 0; i < rows.length; i++) {
+				var row = rows[i];
+				var link = row.querySelector(sel.product_list_link);
+				if (!link) continue;
+
+				var item = {name: link.textContent.trim(), href: link.href, set: '', rarity: '', image: '', price: ''};
+
+				var setEl = sel.product_list_set ? row.querySelector(sel.product_list_set) : null;
+				if (setEl) {
+					item.set = setEl.getAttribute('data-bs-original-title') || setEl.getAttribute('title') || setEl.textContent.trim();
+				}
+
+				var rarityEl = row.querySelector(sel.rarity);
+				item.rarity = rarityEl ? (rarityEl.getAttribute(sel.rarity_attribute) || '') : '';
+
+				// L'image est dans l'infobulle de la miniature, ou dans une balise img
+				var imageEl = sel.product_list_image ? row.querySelector(sel.product_list_image) : null;
+				if (imageEl) {
+					var tooltip = imageEl.getAttribute('data-bs-title') || imageEl.getAttribute('title') || '';
+					var match = tooltip.match(/src="([^"]+)"/);
+					if (match) {
+						item.image = match[1];
+					} else {
+						var img = imageEl.tagName === 'IMG' ? imageEl : imageEl.querySelector('img');
+						item.image = img ? (img.getAttribute('data-echo') || img.src || '') : '';
+					}
+				}
+
+				var priceEl = row.querySelector(sel.product_list_price);
+				item.price = priceEl ? priceEl.textContent.trim() : '';
+
+				result.rows.push(item);
+			}
+
+			var next = sel.product_list_next_page ? document.querySelector(sel.product_list_next_page) : null;
+			if (next && next.href && !next.classList.contains('disabled')) {
+				result.next_url = next.href;
+			}
+
+			if (rows.length === 0) {
+				var bodyText = document.body ? document.body.innerText : '';
+				result.empty_marker = (sel.empty_results_markers || []).some(function(marker) {
+					return bodyText.indexOf(marker) !== -1;
+				});
+			}
+
+			return result;
+		})()
+	`, selectors.jsObject()), &payload))
+	if err != nil {
+		return nil, classifyScrapeError(fmt.Errorf("erreur lors de l'extraction de la liste: %w", err))
+	}
+
+	// Liste vide sans message explicite : dérive de la structure CardMarket
+	if len(payload.Rows) == 0 && !payload.EmptyMarker {
+		reason := fmt.Sprintf("aucune ligne '%s' et aucun message d'absence de résultat", selectors.ProductListRow)
+		details := reason
+		diag, err := a.captureLayoutDrift(ctx, payload.URL, reason, selectors.Version)
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+		}
+		if diag != nil {
+			details = fmt.Sprintf("%s (diagnostic #%d: %s)", reason, diag.ID, diag.HTMLPath)
+		}
+		return nil, newScrapeError(ErrLayoutChanged, ErrLayoutChanged.Error(), details)
+	}
+
+	page := &productListPage{NextURL: payload.NextURL}
+	for _, row := range payload.Rows {
+		// Ignorer les liens qui ne mènent pas à une fiche produit
+		productURL, err := parseCardmarketURL(row.Href)
+		if err != nil {
+			continue
+		}
+
+		// Prix minimum affiché ; s'il est illisible, seul le texte brut est conservé
+		price, err := parsePrice(row.Price, cardmarketPriceHint)
+		if err != nil && row.Price != "" {
+			log.Printf("⚠️  Prix de %q illisible: %v", row.Name, err)
+		}
+
+		page.Products = append(page.Products, SearchCandidate{
+			Name:         strings.TrimSpace(row.Name),
+			Set:          strings.TrimSpace(row.Set),
+			Rarity:       strings.TrimSpace(row.Rarity),
+			ImageURL:     row.Image,
+			ProductURL:   productURL.Canonical,
+			PriceFrom:    row.Price,
+			PriceFromNum: price.Amount,
+			Currency:     price.Currency,
+		})
+	}
+
+	log.Printf("Liste de produits: %d produit(s) sur %s", len(page.Products), payload.URL)
+	return page, nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fichier de profil modifiable par l'utilisateur, prioritaire sur le profil embarqué
+const selectorProfilePath = "./selectors.json"
+
+//go:embed selectors/cardmarket.json
+var defaultSelectorProfile []byte
+
+// SelectorProfile regroupe les sélecteurs CSS utilisés pour lire les pages CardMarket
+type SelectorProfile struct {
+	Version           int      `json:"version"`
+	UpdatedAt         string   `json:"updated_at"`
+	ProductTitle      string   `json:"product_title"`
+	ProductImage      string   `json:"product_image"` // Image du produit, facultative
+	InfoContainer     string   `json:"info_container"`
+	Rarity            string   `json:"rarity"`
+	RarityAttribute   string   `json:"rarity_attribute"`
+	SetLink           string   `json:"set_link"`
+	OfferRow          string   `json:"offer_row"`
+	OfferCondition    string   `json:"offer_condition"`
+	OfferLanguage     string   `json:"offer_language"`
+	OfferFirstEdition string   `json:"offer_first_edition"`
+	OfferPrice        string   `json:"offer_price"`
+	LoadMoreButton    string   `json:"load_more_button"`
+	ProductListRow    string   `json:"product_list_row"` // Listes de produits : recherche et extensions
+	ProductListLink   string   `json:"product_list_link"`
+	ProductListSet    string   `json:"product_list_set"`
+	ProductListImage  string   `json:"product_list_image"`
+	ProductListPrice  string   `json:"product_list_price"`
+	ProductListNext   string   `json:"product_list_next_page"`
+	EmptyOffers       []string `json:"empty_offers_markers"`  // Textes affichés quand aucune offre n'est en vente
+	EmptyResults      []string `json:"empty_results_markers"` // Textes affichés quand une liste de produits est vide
+	CookieBanner      []string `json:"cookie_banner"`
+	Source            string   `json:"source"`            // "embedded" ou chemin du fichier chargé
+	Warning           string   `json:"warning,omitempty"` // Fichier écrit pour une version plus ancienne que le profil embarqué
+}
+
+// validate vérifie que tous les sélecteurs indispensables sont renseignés
+func (p *SelectorProfile) validate() error {
+	if p.Version <= 0 {
+		return fmt.Errorf("version du profil invalide: %d", p.Version)
+	}
+
+	required := map[string]string{
+		"product_title":       p.ProductTitle,
+		"info_container":      p.InfoContainer,
+		"rarity":              p.Rarity,
+		"rarity_attribute":    p.RarityAttribute,
+		"set_link":            p.SetLink,
+		"offer_row":           p.OfferRow,
+		"offer_condition":     p.OfferCondition,
+		"offer_language":      p.OfferLanguage,
+		"offer_first_edition": p.OfferFirstEdition,
+		"offer_price":         p.OfferPrice,
+		"load_more_button":    p.LoadMoreButton,
+		"product_list_row":    p.ProductListRow,
+		"product_list_link":   p.ProductListLink,
+		"product_list_price":  p.ProductListPrice,
+	}
+	for field, value := range required {
+		if value == "" {
+			return fmt.Errorf("sélecteur manquant dans le profil: %s", field)
+		}
+	}
+
+	return nil
+}
+
+// jsObject retourne le profil sous forme d'objet JavaScript à injecter dans les scripts
+func (p *SelectorProfile) jsObject() string {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// jsString retourne une valeur encodée comme littéral de chaîne JavaScript
+func jsString(value string) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func parseSelectorProfile(data []byte, source string) (*SelectorProfile, error) {
+	var profile SelectorProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("profil de sélecteurs illisible (%s): %v", source, err)
+	}
+	if err := profile.validate(); err != nil {
+		return nil, fmt.Errorf("profil de sélecteurs invalide (%s): %v", source, err)
+	}
+	profile.Source = source
+	return &profile, nil
+}
+
+// Intervalle minimal entre deux vérifications du fichier de profil
+const selectorCheckInterval = 5 * time.Second
+
+// selectorStore conserve le profil actif et le recharge quand le fichier change
+type selectorStore struct {
+	mu        sync.Mutex
+	path      string
+	profile   *SelectorProfile
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+func newSelectorStore(path string) *selectorStore {
+	store := &selectorStore{path: path}
+	if _, err := store.Reload(); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	return store
+}
+
+// overlaySelectorProfile applique le fichier de profil par-dessus le profil embarqué, champ par champ :
+// les champs absents, vides ou nuls du fichier gardent leur valeur embarquée.
+// La version reste celle du fichier quand il en déclare une, même plus ancienne que le profil embarqué.
+func overlaySelectorProfile(embedded *SelectorProfile, data []byte, source string) (*SelectorProfile, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("profil de sélecteurs illisible (%s): %v", source, err)
+	}
+	delete(fields, "source")
+	delete(fields, "warning")
+	for name, value := range fields {
+		var text string
+		var list []string
+		if json.Unmarshal(value, &text) == nil && strings.TrimSpace(text) == "" {
+			delete(fields, name)
+		} else if json.Unmarshal(value, &list) == nil && len(list) == 0 {
+			delete(fields, name)
+		}
+	}
+	overlay, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("profil de sélecteurs illisible (%s): %v", source, err)
+	}
+
+	// Copier les listes pour que le fichier ne les modifie pas dans le profil embarqué
+	profile := *embedded
+	profile.EmptyOffers = slices.Clone(embedded.EmptyOffers)
+	profile.EmptyResults = slices.Clone(embedded.EmptyResults)
+	profile.CookieBanner = slices.Clone(embedded.CookieBanner)
+	if err := json.Unmarshal(overlay, &profile); err != nil {
+		return nil, fmt.Errorf("profil de sélecteurs illisible (%s): %v", source, err)
+	}
+	if err := profile.validate(); err != nil {
+		return nil, fmt.Errorf("profil de sélecteurs invalide (%s): %v", source, err)
+	}
+
+	profile.Source = source
+	if profile.Version < embedded.Version {
+		// Correctif écrit pour une version précédente : il reste appliqué, mais l'utilisateur doit le savoir
+		profile.Warning = fmt.Sprintf("profil %s (v%d) plus ancien que le profil embarqué (v%d) : ses sélecteurs restent appliqués, à mettre à jour",
+			source, profile.Version, embedded.Version)
+	}
+	return &profile, nil
+}
+
+// Reload relit le profil depuis le disque, ou revient au profil embarqué
+func (s *selectorStore) Reload() (*SelectorProfile, error) {
+	embedded, err := parseSelectorProfile(defaultSelectorProfile, "embedded")
+	if err != nil {
+		// Le profil embarqué est validé au build, ne devrait jamais arriver
+		log.Fatal(err)
+	}
+
+	profile := embedded
+	var modTime time.Time
+	var loadErr error
+
+	if stat, err := os.Stat(s.path); err == nil {
+		modTime = stat.ModTime()
+		data, err := os.ReadFile(s.path)
+		if err == nil {
+			var fileProfile *SelectorProfile
+			fileProfile, loadErr = overlaySelectorProfile(embedded, data, s.path)
+			if loadErr == nil {
+				profile = fileProfile
+			}
+		} else {
+			loadErr = fmt.Errorf("lecture du profil de sélecteurs impossible: %v", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.modTime = modTime
+	s.checkedAt = time.Now()
+
+	// Un profil invalide ne remplace pas le profil actif
+	if loadErr != nil && s.profile != nil {
+		return s.profile, loadErr
+	}
+
+	s.profile = profile
+	if profile.Warning != "" {
+		log.Printf("⚠️  %s", profile.Warning)
+	}
+	log.Printf("🧩 Profil de sélecteurs v%d chargé (%s)", profile.Version, profile.Source)
+	return profile, loadErr
+}
+
+// Current retourne le profil actif ; le fichier est vérifié au plus toutes les selectorCheckInterval
+// et rechargé s'il a été modifié
+func (s *selectorStore) Current() *SelectorProfile {
+	s.mu.Lock()
+	profile := s.profile
+	if time.Since(s.checkedAt) < selectorCheckInterval {
+		s.mu.Unlock()
+		return profile
+	}
+	s.checkedAt = time.Now()
+	lastModTime := s.modTime
+	s.mu.Unlock()
+
+	var modTime time.Time
+	if stat, err := os.Stat(s.path); err == nil {
+		modTime = stat.ModTime()
+	}
+	if modTime.Equal(lastModTime) {
+		return profile
+	}
+
+	log.Println("🔄 Profil de sélecteurs modifié, rechargement...")
+	reloaded, err := s.Reload()
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	return reloaded
+}
+
+// GetSelectorProfile retourne le profil de sélecteurs actif
+func (a *App) GetSelectorProfile() *SelectorProfile {
+	return a.selectors.Current()
+}
+
+// ReloadSelectors recharge le profil de sélecteurs sans redémarrer l'application
+func (a *App) ReloadSelectors() (*SelectorProfile, error) {
+	return a.selectors.Reload()
+}
@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestOverlaySelectorProfile(t *testing.T) {
+	embedded, err := parseSelectorProfile(defaultSelectorProfile, "embedded")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookieBanner := embedded.CookieBanner[0]
+
+	// Champ vide, liste vide et valeur nulle gardent la valeur embarquée
+	data := []byte(`{"version": 2, "offer_row": ".offer-row", "offer_price": "", "set_link": null,
+		"cookie_banner": [], "empty_offers_markers": ["Rien"], "source": "ailleurs"}`)
+	profile, err := overlaySelectorProfile(embedded, data, "selectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.OfferRow != ".offer-row" {
+		t.Errorf("offer_row %q, attendu celui du fichier", profile.OfferRow)
+	}
+	if profile.OfferPrice != embedded.OfferPrice || profile.SetLink != embedded.SetLink {
+		t.Errorf("offer_price %q et set_link %q, attendu les valeurs embarquées", profile.OfferPrice, profile.SetLink)
+	}
+	if len(profile.CookieBanner) != len(embedded.CookieBanner) || len(profile.EmptyOffers) != 1 {
+		t.Errorf("%d sélecteur(s) de bannière et %d marqueur(s), attendu %d et 1",
+			len(profile.CookieBanner), len(profile.EmptyOffers), len(embedded.CookieBanner))
+	}
+	if embedded.EmptyOffers[0] == "Rien" || embedded.CookieBanner[0] != cookieBanner {
+		t.Error("le fichier a modifié le profil embarqué")
+	}
+	if profile.Source != "selectors.json" {
+		t.Errorf("source %q", profile.Source)
+	}
+
+	// Un fichier plus ancien garde sa version et signale qu'il est à mettre à jour
+	if profile.Version != 2 || profile.Warning == "" {
+		t.Errorf("version %d, avertissement %q, attendu v2 et un avertissement", profile.Version, profile.Warning)
+	}
+
+	// Sans version déclarée, le fichier complète le profil embarqué sans avertissement
+	profile, err = overlaySelectorProfile(embedded, []byte(`{"offer_row": ".offer-row"}`), "selectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Version != embedded.Version || profile.Warning != "" {
+		t.Errorf("version %d, avertissement %q, attendu v%d sans avertissement", profile.Version, profile.Warning, embedded.Version)
+	}
+
+	if _, err := overlaySelectorProfile(embedded, []byte(`{"version": 0}`), "selectors.json"); err == nil {
+		t.Error("version 0 acceptée")
+	}
+}
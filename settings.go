@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Valeurs par défaut des réglages de scraping
+const (
+	defaultMaxOfferRows   = 500
+	defaultMaxLoadSeconds = 60
+)
+
+// ScrapeSettings regroupe les limites appliquées au chargement des offres
+type ScrapeSettings struct {
+	MaxOfferRows   int `json:"max_offer_rows"`   // Nombre maximal de lignes d'offres chargées par carte
+	MaxLoadSeconds int `json:"max_load_seconds"` // Durée maximale du chargement des offres par carte
+}
+
+func (s ScrapeSettings) maxLoadDuration() time.Duration {
+	return time.Duration(s.MaxLoadSeconds) * time.Second
+}
+
+// getSetting lit un réglage, ou retourne la valeur par défaut s'il n'existe pas
+func (a *App) getSetting(key, defaultValue string) string {
+	var value string
+	err := a.db.QueryRow("SELECT value FROM settings WHERE key = ?", key).Scan(&value)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// setSetting enregistre un réglage
+func (a *App) setSetting(key, value string) error {
+	_, err := a.db.Exec(`
+		INSERT INTO settings (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value
+	`, key, value)
+	return err
+}
+
+func (a *App) getIntSetting(key string, defaultValue int) int {
+	value, err := strconv.Atoi(a.getSetting(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getScrapeSettings retourne les réglages de scraping actifs
+func (a *App) getScrapeSettings() ScrapeSettings {
+	return ScrapeSettings{
+		MaxOfferRows:   a.getIntSetting("scrape.max_offer_rows", defaultMaxOfferRows),
+		MaxLoadSeconds: a.getIntSetting("scrape.max_load_seconds", defaultMaxLoadSeconds),
+	}
+}
+
+// GetScrapeSettings retourne les réglages de scraping
+func (a *App) GetScrapeSettings() ScrapeSettings {
+	return a.getScrapeSettings()
+}
+
+// UpdateScrapeSettings modifie les limites de chargement des offres
+func (a *App) UpdateScrapeSettings(settings ScrapeSettings) (*ScrapeSettings, error) {
+	if settings.MaxOfferRows <= 0 {
+		return nil, fmt.Errorf("le nombre maximal d'offres doit être positif")
+	}
+	if settings.MaxLoadSeconds <= 0 {
+		return nil, fmt.Errorf("la durée maximale de chargement doit être positive")
+	}
+
+	if err := a.setSetting("scrape.max_offer_rows", strconv.Itoa(settings.MaxOfferRows)); err != nil {
+		return nil, fmt.Errorf("erreur sauvegarde réglages: %v", err)
+	}
+	if err := a.setSetting("scrape.max_load_seconds", strconv.Itoa(settings.MaxLoadSeconds)); err != nil {
+		return nil, fmt.Errorf("erreur sauvegarde réglages: %v", err)
+	}
+
+	current := a.getScrapeSettings()
+	return &current, nil
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Nombre de cartes par défaut et maximal des classements de GetStatsReport
+const (
+	defaultStatsTopN = 10
+	maxStatsTopN     = 100
+)
+
+// Bornes des tranches de valeur, dans la devise d'affichage ; la dernière tranche n'a pas de borne haute
+var valueBucketBounds = []float64{1, 5, 10, 25, 50, 100, 250}
+
+// Regroupements calculés par GetStatsReport : clé du rapport et expression SQL du groupe
+var statsGroupings = []struct {
+	key  string
+	expr string
+}{
+	{"set_name", "COALESCE(cards.set_name, '')"},
+	{"rarity", "COALESCE(cards.rarity, '')"},
+	{"language", "COALESCE(cards.language, '')"},
+	{"quality", "COALESCE(cards.quality, '')"},
+	{"edition", "CASE WHEN COALESCE(cards.edition, FALSE) THEN 'edition' ELSE 'standard' END"},
+}
+
+// StatsGroup est le nombre et la valeur des cartes d'un groupe (une extension, une rareté, ...)
+type StatsGroup struct {
+	Key     string  `json:"key"` // Vide si la valeur n'est pas renseignée
+	Count   int     `json:"count"`
+	Value   float64 `json:"value"`
+	Average float64 `json:"average"`
+}
+
+// ValueBucket est une tranche de valeur de carte
+type ValueBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"` // 0 pour la dernière tranche, sans borne haute
+	Count int     `json:"count"`
+	Value float64 `json:"value"`
+}
+
+// CardValue est une carte et sa valeur dans la devise d'affichage
+type CardValue struct {
+	Card  Card    `json:"card"`
+	Value float64 `json:"value"`
+}
+
+// PriceMover est une carte dont le prix a changé au dernier rescrap
+type PriceMover struct {
+	Card          Card    `json:"card"`
+	Previous      float64 `json:"previous"` // Dans la devise d'affichage
+	Current       float64 `json:"current"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"change_percent"` // 0 si le prix précédent était nul
+}
+
+// StatsReport détaille la valeur des cartes sélectionnées, dans la devise d'affichage
+type StatsReport struct {
+	Currency     string                  `json:"currency"`
+	Count        int                     `json:"count"`
+	PricedCount  int                     `json:"priced_count"` // Cartes dont la valeur est connue
+	Value        float64                 `json:"value"`
+	Average      float64                 `json:"average"`
+	Groups       map[string][]StatsGroup `json:"groups"` // Par set_name, rarity, language, quality et edition
+	TopCards     []CardValue             `json:"top_cards"`
+	Movers       []PriceMover            `json:"movers"` // Plus fortes variations au dernier rescrap
+	Distribution []ValueBucket           `json:"distribution"`
+	MissingRates []string                `json:"missing_rates"` // Devises exclues des valeurs faute de taux
+}
+
+// GetStatsReport calcule en SQL les statistiques détaillées des cartes sélectionnées par query
+// (tri et pagination ignorés) : regroupements, cartes les plus chères, plus fortes variations et répartition
+func (a *App) GetStatsReport(query CardQuery, topN int) (*StatsReport, error) {
+	if topN <= 0 {
+		topN = defaultStatsTopN
+	}
+	if topN > maxStatsTopN {
+		topN = maxStatsTopN
+	}
+
+	rates, err := a.exchangeRates()
+	if err != nil {
+		return nil, err
+	}
+	display := a.getDisplayCurrency()
+	valueExpr, valueArgs := displayPriceExpr(rates, display)
+	previousExpr, previousArgs := convertedAmountExpr("previous_price_num", "previous_currency", rates, display)
+
+	where, whereArgs, err := query.where(valueExpr, valueArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cartes sélectionnées avec leur valeur convertie, réutilisées par chaque requête
+	scope := `WITH scope AS (
+		SELECT id AS card_id, ` + valueExpr + ` AS value,
+		       CASE WHEN previous_price_num IS NULL THEN NULL ELSE ` + previousExpr + ` END AS previous_value
+		FROM cards WHERE ` + where + `
+	) `
+	scopeArgs := append(append(append([]any{}, valueArgs...), previousArgs...), whereArgs...)
+
+	report := &StatsReport{
+		Currency:     display,
+		Groups:       map[string][]StatsGroup{},
+		TopCards:     []CardValue{},
+		Movers:       []PriceMover{},
+		MissingRates: []string{},
+	}
+
+	err = a.db.QueryRow(scope+`SELECT COUNT(*), COUNT(value), COALESCE(SUM(value), 0), COALESCE(AVG(value), 0) FROM scope`, scopeArgs...).
+		Scan(&report.Count, &report.PricedCount, &report.Value, &report.Average)
+	if err != nil {
+		return nil, fmt.Errorf("erreur calcul des statistiques: %v", err)
+	}
+
+	rows, err := a.db.Query(scope+`
+		SELECT DISTINCT COALESCE(NULLIF(cards.currency, ''), 'EUR')
+		FROM scope JOIN cards ON cards.id = scope.card_id
+		WHERE scope.value IS NULL AND cards.price_num IS NOT NULL
+		ORDER BY 1
+	`, scopeArgs...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var currency string
+		if err := rows.Scan(&currency); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		report.MissingRates = append(report.MissingRates, currency)
+	}
+	rows.Close()
+
+	for _, grouping := range statsGroupings {
+		groups, err := a.statsGroups(scope, scopeArgs, grouping.expr)
+		if err != nil {
+			return nil, err
+		}
+		report.Groups[grouping.key] = groups
+	}
+
+	if report.TopCards, err = a.topCardValues(scope, scopeArgs, topN); err != nil {
+		return nil, err
+	}
+	if report.Movers, err = a.priceMovers(scope, scopeArgs, topN); err != nil {
+		return nil, err
+	}
+	if report.Distribution, err = a.valueDistribution(scope, scopeArgs); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// statsGroups compte et additionne la valeur des cartes par groupe, les groupes les plus chers d'abord
+func (a *App) statsGroups(scope string, scopeArgs []any, groupExpr string) ([]StatsGroup, error) {
+	rows, err := a.db.Query(scope+`
+		SELECT `+groupExpr+` AS grp, COUNT(*), COALESCE(SUM(scope.value), 0), COALESCE(AVG(scope.value), 0)
+		FROM scope JOIN cards ON cards.id = scope.card_id
+		GROUP BY grp
+		ORDER BY 3 DESC, 2 DESC, grp
+	`, scopeArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur calcul des statistiques: %v", err)
+	}
+	defer rows.Close()
+
+	groups := []StatsGroup{}
+	for rows.Next() {
+		var group StatsGroup
+		if err := rows.Scan(&group.Key, &group.Count, &group.Value, &group.Average); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// topCardValues retourne les cartes les plus chères
+func (a *App) topCardValues(scope string, scopeArgs []any, limit int) ([]CardValue, error) {
+	rows, err := a.db.Query(scope+`
+		SELECT `+cardColumns+`, scope.value
+		FROM scope JOIN cards ON cards.id = scope.card_id
+		WHERE scope.value IS NOT NULL
+		ORDER BY scope.value DESC, cards.id
+		LIMIT ?
+	`, append(append([]any{}, scopeArgs...), limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur calcul des statistiques: %v", err)
+	}
+	defer rows.Close()
+
+	cards := []CardValue{}
+	for rows.Next() {
+		var value CardValue
+		card, err := scanCard(extraColumns{rows, []any{&value.Value}})
+		if err != nil {
+			return nil, err
+		}
+		value.Card = *card
+		cards = append(cards, value)
+	}
+	return cards, rows.Err()
+}
+
+// priceMovers retourne les cartes dont la valeur a le plus varié au dernier rescrap, hausses et baisses confondues
+func (a *App) priceMovers(scope string, scopeArgs []any, limit int) ([]PriceMover, error) {
+	rows, err := a.db.Query(scope+`
+		SELECT `+cardColumns+`, scope.previous_value, scope.value, scope.value - scope.previous_value,
+		       CASE WHEN scope.previous_value > 0 THEN (scope.value - scope.previous_value) * 100.0 / scope.previous_value ELSE 0 END
+		FROM scope JOIN cards ON cards.id = scope.card_id
+		WHERE scope.value IS NOT NULL AND scope.previous_value IS NOT NULL AND scope.value != scope.previous_value
+		ORDER BY ABS(scope.value - scope.previous_value) DESC, cards.id
+		LIMIT ?
+	`, append(append([]any{}, scopeArgs...), limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur calcul des statistiques: %v", err)
+	}
+	defer rows.Close()
+
+	movers := []PriceMover{}
+	for rows.Next() {
+		var mover PriceMover
+		card, err := scanCard(extraColumns{rows, []any{&mover.Previous, &mover.Current, &mover.Change, &mover.ChangePercent}})
+		if err != nil {
+			return nil, err
+		}
+		mover.Card = *card
+		movers = append(movers, mover)
+	}
+	return movers, rows.Err()
+}
+
+// valueDistribution répartit les cartes de valeur connue en tranches ; les tranches vides sont incluses
+func (a *App) valueDistribution(scope string, scopeArgs []any) ([]ValueBucket, error) {
+	buckets := make([]ValueBucket, len(valueBucketBounds)+1)
+	var bucketExpr strings.Builder
+	bucketExpr.WriteString("CASE")
+	for i, bound := range valueBucketBounds {
+		fmt.Fprintf(&bucketExpr, " WHEN value < %g THEN %d", bound, i)
+		buckets[i].Max = bound
+		buckets[i+1].Min = bound
+	}
+	fmt.Fprintf(&bucketExpr, " ELSE %d END", len(valueBucketBounds))
+
+	rows, err := a.db.Query(scope+`
+		SELECT `+bucketExpr.String()+` AS bucket, COUNT(*), SUM(value)
+		FROM scope
+		WHERE value IS NOT NULL
+		GROUP BY bucket
+	`, scopeArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur calcul des statistiques: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bucket, count int
+		var value float64
+		if err := rows.Scan(&bucket, &count, &value); err != nil {
+			return nil, err
+		}
+		buckets[bucket].Count = count
+		buckets[bucket].Value = value
+	}
+	return buckets, rows.Err()
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Séparateur des étiquettes concaténées par cardColumns (caractère "unit separator")
+const tagSeparator = "\x1f"
+
+// CardFilter restreint les cartes retournées par GetCards et comptées par GetStats
+type CardFilter struct {
+	Tags []string `json:"tags"` // La carte doit porter toutes ces étiquettes
+	Note string   `json:"note"` // Texte contenu dans la note, insensible à la casse
+}
+
+// TagInfo est une étiquette et le nombre de cartes qui la portent
+type TagInfo struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// where retourne les conditions SQL du filtre, à ajouter après un WHERE portant sur la table cards
+func (f CardFilter) where() (string, []any) {
+	var clauses []string
+	var args []any
+	for _, tag := range f.Tags {
+		tag = normalizeTag(tag)
+		if tag == "" {
+			continue
+		}
+		clauses = append(clauses, "cards.id IN (SELECT ct.card_id FROM card_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name = ?)")
+		args = append(args, tag)
+	}
+	if note := strings.TrimSpace(f.Note); note != "" {
+		clauses = append(clauses, `cards.id IN (SELECT card_id FROM card_notes WHERE note LIKE ? ESCAPE '\')`)
+		args = append(args, "%"+escapeLike(note)+"%")
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " AND " + strings.Join(clauses, " AND "), args
+}
+
+// escapeLike protège les caractères spéciaux d'un motif LIKE
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// normalizeTag ramène une étiquette à sa forme enregistrée : minuscules, espaces remplacés par des tirets
+func normalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
+
+// splitTags décompose les étiquettes concaténées d'une carte, triées
+func splitTags(concatenated string) []string {
+	tags := []string{}
+	if concatenated == "" {
+		return tags
+	}
+	tags = strings.Split(concatenated, tagSeparator)
+	sort.Strings(tags)
+	return tags
+}
+
+// GetTags retourne toutes les étiquettes utilisées et leur nombre de cartes
+func (a *App) GetTags() ([]TagInfo, error) {
+	rows, err := a.db.Query(`
+		SELECT t.name, COUNT(ct.card_id)
+		FROM tags t
+		LEFT JOIN card_tags ct ON ct.tag_id = t.id
+		GROUP BY t.id
+		ORDER BY t.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []TagInfo{}
+	for rows.Next() {
+		var tag TagInfo
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// AddTag ajoute une étiquette à une carte ; l'étiquette est créée si besoin
+func (a *App) AddTag(cardID int, tag string) (*Card, error) {
+	tag = normalizeTag(tag)
+	if tag == "" {
+		return nil, fmt.Errorf("étiquette vide")
+	}
+	if _, err := a.getCardByID(cardID); err != nil {
+		return nil, fmt.Errorf("carte %d introuvable: %v", cardID, err)
+	}
+
+	if _, err := a.db.Exec("INSERT OR IGNORE INTO tags (name) VALUES (?)", tag); err != nil {
+		return nil, fmt.Errorf("erreur création de l'étiquette: %v", err)
+	}
+	_, err := a.db.Exec(`
+		INSERT OR IGNORE INTO card_tags (card_id, tag_id)
+		SELECT ?, id FROM tags WHERE name = ?
+	`, cardID, tag)
+	if err != nil {
+		return nil, fmt.Errorf("erreur sauvegarde de l'étiquette: %v", err)
+	}
+	return a.getCardByID(cardID)
+}
+
+// RemoveTag retire une étiquette d'une carte
+func (a *App) RemoveTag(cardID int, tag string) (*Card, error) {
+	_, err := a.db.Exec(`
+		DELETE FROM card_tags
+		WHERE card_id = ? AND tag_id = (SELECT id FROM tags WHERE name = ?)
+	`, cardID, normalizeTag(tag))
+	if err != nil {
+		return nil, fmt.Errorf("erreur suppression de l'étiquette: %v", err)
+	}
+	return a.getCardByID(cardID)
+}
+
+// DeleteTag supprime une étiquette de toutes les cartes
+func (a *App) DeleteTag(tag string) error {
+	tag = normalizeTag(tag)
+	if _, err := a.db.Exec("DELETE FROM card_tags WHERE tag_id = (SELECT id FROM tags WHERE name = ?)", tag); err != nil {
+		return fmt.Errorf("erreur suppression de l'étiquette: %v", err)
+	}
+	_, err := a.db.Exec("DELETE FROM tags WHERE name = ?", tag)
+	return err
+}
+
+// SetCardNote remplace la note d'une carte ; une note vide la supprime
+func (a *App) SetCardNote(cardID int, note string) (*Card, error) {
+	if _, err := a.getCardByID(cardID); err != nil {
+		return nil, fmt.Errorf("carte %d introuvable: %v", cardID, err)
+	}
+
+	var err error
+	if strings.TrimSpace(note) == "" {
+		_, err = a.db.Exec("DELETE FROM card_notes WHERE card_id = ?", cardID)
+	} else {
+		_, err = a.db.Exec(`
+			INSERT INTO card_notes (card_id, note, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT(card_id) DO UPDATE SET note = excluded.note, updated_at = excluded.updated_at
+		`, cardID, note)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erreur sauvegarde de la note: %v", err)
+	}
+	return a.getCardByID(cardID)
+}
+
+// deleteCardAnnotations supprime les étiquettes et la note d'une carte supprimée
+func (a *App) deleteCardAnnotations(cardID int) error {
+	if _, err := a.db.Exec("DELETE FROM card_tags WHERE card_id = ?", cardID); err != nil {
+		return err
+	}
+	_, err := a.db.Exec("DELETE FROM card_notes WHERE card_id = ?", cardID)
+	return err
+}
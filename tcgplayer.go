@@ -0,0 +1,288 @@
+package main
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Préfixes des slugs produit TCGplayer pour chaque jeu
+var tcgplayerGamePrefixes = map[string]string{
+	"yugioh-":              "YuGiOh",
+	"magic-":               "Magic",
+	"pokemon-":             "Pokemon",
+	"one-piece-card-game-": "OnePiece",
+	"lorcana-tcg-":         "Lorcana",
+	"flesh-and-blood-tcg-": "FleshAndBlood",
+	"digimon-card-game-":   "Digimon",
+}
+
+// Impression TCGplayer correspondant à l'attribut spécial de chaque jeu
+var tcgplayerPrintings = map[string]string{
+	"YuGiOh":        "1st Edition",
+	"Magic":         "Foil",
+	"Pokemon":       "Reverse Holofoil",
+	"Lorcana":       "Cold Foil",
+	"FleshAndBlood": "Rainbow Foil",
+}
+
+// États TCGplayer ramenés à l'échelle CardMarket utilisée par les critères
+var tcgplayerConditions = []struct {
+	Label string
+	Code  string
+}{
+	{"Near Mint", "NM"},
+	{"Lightly Played", "EX"},
+	{"Moderately Played", "GD"},
+	{"Heavily Played", "PL"},
+	{"Damaged", "PO"},
+}
+
+// Langues TCGplayer et libellés utilisés par les critères
+var tcgplayerLanguages = map[string]string{
+	"English":     "English",
+	"French":      "Français",
+	"German":      "Deutsch",
+	"Spanish":     "Español",
+	"Italian":     "Italiano",
+	"Japanese":    "日本語",
+	"Portuguese":  "Português",
+	"Russian":     "Русский",
+	"Korean":      "한국어",
+	"Chinese (S)": "S-Chinese",
+	"Chinese (T)": "T-Chinese",
+}
+
+var tcgplayerProductPath = regexp.MustCompile(`^/product/(\d+)(?:/([a-z0-9-]+))?/?$`)
+
+// tcgplayerMarketplace lit les pages produit de tcgplayer.com (prix en dollars)
+type tcgplayerMarketplace struct{}
+
+func (m *tcgplayerMarketplace) ID() string       { return "tcgplayer" }
+func (m *tcgplayerMarketplace) Name() string     { return "TCGplayer" }
+func (m *tcgplayerMarketplace) Currency() string { return "USD" }
+func (m *tcgplayerMarketplace) Locale() string   { return "en-US" }
+
+func (m *tcgplayerMarketplace) Recognizes(rawURL string) bool {
+	parsed, err := parseLooseURL(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	return host == "tcgplayer.com" || host == "www.tcgplayer.com"
+}
+
+func (m *tcgplayerMarketplace) ParseURL(rawURL string) (*ProductURL, error) {
+	parsed, err := parseLooseURL(rawURL)
+	if err != nil {
+		return nil, newScrapeError(ErrInvalidProductURL, "URL invalide", err.Error())
+	}
+
+	matches := tcgplayerProductPath.FindStringSubmatch(parsed.Path)
+	if matches == nil {
+		return nil, newScrapeError(ErrInvalidProductURL, "cette URL n'est pas une page produit TCGplayer", rawURL)
+	}
+
+	productURL := &ProductURL{
+		Marketplace: m.ID(),
+		Canonical:   "https://www.tcgplayer.com/product/" + matches[1],
+	}
+	if slug := matches[2]; slug != "" {
+		productURL.Canonical += "/" + slug
+		for prefix, game := range tcgplayerGamePrefixes {
+			if strings.HasPrefix(slug, prefix) {
+				productURL.Game = game
+				break
+			}
+		}
+	}
+
+	// Critères présents dans les filtres de l'URL
+	query := parsed.Query()
+	for label, language := range tcgplayerLanguages {
+		if query.Get("Language") == label {
+			productURL.Criteria.Language = language
+		}
+	}
+	for _, condition := range tcgplayerConditions {
+		if query.Get("Condition") == condition.Label {
+			productURL.Criteria.Quality = condition.Code
+		}
+	}
+	if printing, ok := tcgplayerPrintings[productURL.Game]; ok && query.Get("Printing") == printing {
+		productURL.Criteria.Edition = true
+	}
+
+	return productURL, nil
+}
+
+func (m *tcgplayerMarketplace) FilteredURL(productURL string, req AddCardRequest) (string, bool) {
+	parsedProduct, err := m.ParseURL(productURL)
+	if err != nil {
+		return productURL, false
+	}
+	parsed, err := url.Parse(parsedProduct.Canonical)
+	if err != nil {
+		return productURL, false
+	}
+
+	query := url.Values{}
+	for label, language := range tcgplayerLanguages {
+		if language == req.Language {
+			query.Set("Language", label)
+			break
+		}
+	}
+	for _, condition := range tcgplayerConditions {
+		if condition.Code == req.Quality {
+			query.Set("Condition", condition.Label)
+		}
+	}
+	if printing, ok := tcgplayerPrintings[parsedProduct.Game]; ok && req.Edition {
+		query.Set("Printing", printing)
+	}
+
+	if len(query) == 0 {
+		return productURL, false
+	}
+
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), true
+}
+
+// IsNotFoundPage reconnaît la page d'erreur affichée pour un produit inexistant
+func (m *tcgplayerMarketplace) IsNotFoundPage(title string) bool {
+	title = strings.ToLower(title)
+	return strings.Contains(title, "404") || strings.Contains(title, "page not found")
+}
+
+// CookieBanner ne retourne aucun sélecteur : les pages produit TCGplayer ne sont pas masquées par une bannière
+func (m *tcgplayerMarketplace) CookieBanner() []string {
+	return nil
+}
+
+// ExtractScript lit l'en-tête et les annonces affichées par l'application TCGplayer
+func (m *tcgplayerMarketplace) ExtractScript() string {
+	return `
+		(function() {
+			var result = {title: document.title, url: window.location.href, lang: document.documentElement.lang || '', name: '', rarity: '', set_name: '', rows: []};
+
+			var h1 = document.querySelector('h1.product-details__name');
+			result.has_title = h1 !== null;
+			result.name = h1 ? h1.innerText.trim() : '';
+
+			var img = document.querySelector('.product-details__image img, .lazy-image__wrapper img');
+			result.image_url = img ? (img.getAttribute('data-src') || img.getAttribute('src') || '') : '';
+
+			var setEl = document.querySelector('.product-details__name__sub-header__links a');
+			result.set_name = setEl ? setEl.textContent.trim() : '';
+
+			// Rareté : attribut "Rarity" de la fiche produit
+			var attributes = document.querySelectorAll('.product__item-details__attributes li');
+			for (var i = 0; i < attributes.length; i++) {
+				var text = attributes[i].innerText || '';
+				if (text.indexOf('Rarity') === 0) {
+					result.rarity = text.replace(/^Rarity:?/, '').trim();
+				}
+			}
+
+			var rows = document.querySelectorAll('.listing-item');
+			for (var i = 0; i < rows.length; i++) {
+				var row = rows[i];
+				var offer = {};
+				try {
+					var conditionEl = row.querySelector('.listing-item__listing-data__info__condition');
+					offer.mint = conditionEl ? conditionEl.textContent.trim() : '';
+
+					var priceEl = row.querySelector('.listing-item__listing-data__info__price');
+					offer.price = priceEl ? priceEl.textContent.trim() : '';
+
+					offer.success = true;
+				} catch(e) {
+					offer.error = e.toString();
+					offer.success = false;
+				}
+				result.rows.push(offer);
+			}
+
+			if (rows.length === 0) {
+				var bodyText = document.body ? document.body.innerText : '';
+				result.empty_marker = bodyText.indexOf('No Listings') !== -1 || bodyText.indexOf('0 Listings') !== -1;
+			}
+
+			return result;
+		})()
+	`
+}
+
+func (m *tcgplayerMarketplace) ParseProduct(payload *pagePayload) (*pageExtraction, string) {
+	game := ""
+	if parsed, err := m.ParseURL(payload.URL); err == nil {
+		game = parsed.Game
+	}
+
+	page := &pageExtraction{
+		Name:     strings.TrimSpace(payload.Name),
+		Rarity:   normalizeRarity(game, payload.Rarity),
+		SetName:  strings.TrimSpace(payload.SetName),
+		ImageURL: resolveImageURL(payload.URL, payload.ImageURL),
+		RowCount: len(payload.Rows),
+	}
+
+	if !payload.HasTitle {
+		return page, "aucun titre produit 'h1.product-details__name' sur la page"
+	}
+	if page.RowCount == 0 && !payload.EmptyMarker {
+		return page, "aucune annonce '.listing-item' et aucun message d'absence d'annonce"
+	}
+
+	return page, ""
+}
+
+// ParseOffers décompose le libellé d'état TCGplayer, par exemple "Near Mint 1st Edition - Japanese"
+func (m *tcgplayerMarketplace) ParseOffers(payload *pagePayload, page *pageExtraction) []CardOffer {
+	game := ""
+	if parsed, err := m.ParseURL(payload.URL); err == nil {
+		game = parsed.Game
+	}
+
+	var offers []CardOffer
+	for _, row := range payload.Rows {
+		if !row.Success {
+			continue
+		}
+
+		label := strings.TrimSpace(row.Mint)
+		language := "English"
+		if idx := strings.LastIndex(label, " - "); idx != -1 {
+			if mapped, ok := tcgplayerLanguages[strings.TrimSpace(label[idx+3:])]; ok {
+				language = mapped
+			}
+			label = strings.TrimSpace(label[:idx])
+		}
+
+		quality, printing := "", label
+		for _, condition := range tcgplayerConditions {
+			if strings.HasPrefix(label, condition.Label) {
+				quality = condition.Code
+				printing = strings.TrimSpace(strings.TrimPrefix(label, condition.Label))
+				break
+			}
+		}
+
+		var specials []string
+		if printing != "" {
+			specials = []string{printing}
+		}
+
+		offers = append(offers, CardOffer{
+			Mint:     quality,
+			Language: language,
+			Edition:  offerHasEdition(game, false, specials),
+			Price:    strings.TrimSpace(row.Price),
+			Rarity:   page.Rarity,
+			SetName:  page.SetName,
+		})
+	}
+	return offers
+}
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestTcgplayerFixtures(t *testing.T) {
+	testMarketplaceFixtures(t, testMarketplace(t, "tcgplayer"))
+}
+
+func TestTcgplayerParseURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		canonical string
+		game      string
+		criteria  CardCriteria
+	}{
+		{
+			url:       "https://www.tcgplayer.com/product/21699/yugioh-legend-of-blue-eyes-white-dragon-dark-magician?page=1",
+			canonical: "https://www.tcgplayer.com/product/21699/yugioh-legend-of-blue-eyes-white-dragon-dark-magician",
+			game:      "YuGiOh",
+		},
+		{
+			url:       "tcgplayer.com/product/21699/yugioh-legend-of-blue-eyes-white-dragon-dark-magician?Language=Japanese&Condition=Lightly+Played&Printing=1st+Edition",
+			canonical: "https://www.tcgplayer.com/product/21699/yugioh-legend-of-blue-eyes-white-dragon-dark-magician",
+			game:      "YuGiOh",
+			criteria:  CardCriteria{Quality: "EX", Language: "日本語", Edition: true},
+		},
+		{
+			url:       "https://www.tcgplayer.com/product/12345",
+			canonical: "https://www.tcgplayer.com/product/12345",
+		},
+	}
+
+	market := testMarketplace(t, "tcgplayer")
+	for _, tt := range tests {
+		parsed, err := market.ParseURL(tt.url)
+		if err != nil {
+			t.Errorf("%s: %v", tt.url, err)
+			continue
+		}
+		if parsed.Canonical != tt.canonical || parsed.Game != tt.game || parsed.Criteria != tt.criteria {
+			t.Errorf("%s: %s/%s/%+v, attendu %s/%s/%+v", tt.url,
+				parsed.Canonical, parsed.Game, parsed.Criteria, tt.canonical, tt.game, tt.criteria)
+		}
+	}
+
+	if _, err := market.ParseURL("https://www.tcgplayer.com/search/yugioh/product"); err == nil {
+		t.Error("une page de recherche ne doit pas être acceptée comme page produit")
+	}
+}